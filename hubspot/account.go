@@ -0,0 +1,57 @@
+package hubspot
+
+import (
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// AccountInfo - details of the hubspot portal
+type AccountInfo struct {
+	PortalID             int64
+	AccountType          string // eg. STANDARD, DEVELOPER_TEST, SANDBOX
+	TimeZone             string // iana name of portal timezone (eg. Europe/Berlin)
+	Currency             string // company currency (eg. EUR)
+	AdditionalCurrencies []string
+	UTCOffset            string // offset of portal timezone (eg. +01:00)
+	DataHostingLocation  string // location of data center hosting the portal (eg. na1, eu1)
+	UIDomain             string // domain of the hubspot ui for the portal (eg. app-eu1.hubspot.com)
+}
+
+// Location - get the location of the portal timezone
+func (info *AccountInfo) Location() (*time.Location, error) {
+	return time.LoadLocation(info.TimeZone)
+}
+
+// IAccount - interface for the hubspot account info api
+type IAccount interface {
+	Details() (*AccountInfo, error)
+}
+
+// Account - access to account information of hubspot using rest
+type Account struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewAccount - creates a new account api
+func NewAccount(rest IRestClient) *Account {
+	return &Account{rest: rest}
+}
+
+// Details - get details of the portal the client is authorized for
+func (api *Account) Details() (*AccountInfo, error) {
+	response, err := api.rest.Get("account-info/v3/details")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountInfo{
+		PortalID:             cast.ToInt64(response["portalId"]),
+		AccountType:          cast.ToString(response["accountType"]),
+		TimeZone:             cast.ToString(response["timeZone"]),
+		Currency:             cast.ToString(response["companyCurrency"]),
+		AdditionalCurrencies: cast.ToStringSlice(response["additionalCurrencies"]),
+		UTCOffset:            cast.ToString(response["utcOffset"]),
+		DataHostingLocation:  cast.ToString(response["dataHostingLocation"]),
+		UIDomain:             cast.ToString(response["uiDomain"])}, nil
+}
@@ -0,0 +1,73 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseAccountDetails string = `{
+	"portalId": 62515,
+	"accountType": "STANDARD",
+	"timeZone": "Europe/Berlin",
+	"companyCurrency": "EUR",
+	"additionalCurrencies": ["USD", "CHF"],
+	"utcOffset": "+01:00",
+	"utcOffsetMilliseconds": 3600000,
+	"uiDomain": "app-eu1.hubspot.com",
+	"dataHostingLocation": "eu1"
+  }`
+
+func TestAccountInterfaceImpl(t *testing.T) {
+	var account IAccount = &Account{}
+
+	if account != nil {
+		return
+	}
+}
+
+func TestAccountDetails(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseAccountDetails)}
+	api := NewAccount(rest)
+
+	info, err := api.Details()
+	require.NoError(t, err)
+	require.Equal(t, "GET account-info/v3/details?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, int64(62515), info.PortalID)
+	require.Equal(t, "EUR", info.Currency)
+	require.Equal(t, []string{"USD", "CHF"}, info.AdditionalCurrencies)
+	require.Equal(t, "eu1", info.DataHostingLocation)
+
+	location, err := info.Location()
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Berlin", location.String())
+}
+
+func TestPortalTimeLocation(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	type LocatedCompany struct {
+		Created time.Time `hubspot:"name=createdate"`
+	}
+	response := map[string]interface{}{
+		"id":         "512",
+		"properties": map[string]interface{}{"createdate": "1585735200000"}}
+
+	result, err := objectToEntity(response, MustNewModelWithOptions(reflect.TypeOf(LocatedCompany{}), Options{Location: location}))
+	require.NoError(t, err)
+	converted := result.(*LocatedCompany).Created
+	require.Equal(t, location, converted.Location())
+	require.Equal(t, 12, converted.Hour())
+
+	// other models are not affected by the location
+	result, err = objectToEntity(response, MustNewModel(reflect.TypeOf(LocatedCompany{})))
+	require.NoError(t, err)
+	require.Equal(t, 10, result.(*LocatedCompany).Created.Hour())
+
+	timestamp, err := toTime("1585735200000")
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, timestamp.Location())
+}
@@ -50,6 +50,10 @@ const (
 	AssociationCompanyToPartner            AssociationType = 44
 	AssociationResellerToCompany           AssociationType = 45
 	AssociationCompanyToReseller           AssociationType = 46
+	AssociationDealToQuote                 AssociationType = 63
+	AssociationQuoteToDeal                 AssociationType = 64
+	AssociationQuoteToLineItem             AssociationType = 67
+	AssociationLineItemToQuote             AssociationType = 68
 )
 
 // Association - association of objects in hubspot
@@ -64,6 +68,7 @@ type IAssociations interface {
 	Create(fromid int64, toid int64, asstype AssociationType) error
 	CreateBulk(data []*Association) error
 	List(objectid int64, asstype AssociationType, page *Page) (*PageResponse, error)
+	ListAll(objectid int64, asstype AssociationType) ([]int64, error)
 	Delete(fromid int64, toid int64, asstype AssociationType) error
 	DeleteBulk(data []*Association) error
 }
@@ -138,6 +143,64 @@ func (api *Associations) List(objectid int64, asstype AssociationType, page *Pag
 	return pr, nil
 }
 
+// ListAll - lists ids of all objects associated to an object
+func (api *Associations) ListAll(objectid int64, asstype AssociationType) ([]int64, error) {
+	var ids []int64
+	var page *Page
+	for {
+		response, err := api.List(objectid, asstype, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range response.Data {
+			ids = append(ids, id.(int64))
+		}
+
+		if !response.HasMore {
+			return ids, nil
+		}
+		page = NewPage(response.Offset, 0)
+	}
+}
+
+// listAssociated - lists a page of objects associated to an object
+// ids are listed using the associations api and the associated objects are read in batches
+//
+// **Parameters**
+//   rest      : client used to send requests
+//   objectid  : id of object of which to list associated objects
+//   asstype   : type of association to list
+//   page      : page of associations to list (nil to list the first page with default size)
+//   objecttype: type of associated objects (eg. contacts, companies, deals)
+//   model     : model used to deserialize associated objects
+func listAssociated(rest IRestClient, objectid int64, asstype AssociationType, page *Page, objecttype string, model *Model) (*PageResponse, error) {
+	associations, err := NewAssociations(rest).List(objectid, asstype, page)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &PageResponse{
+		HasMore: associations.HasMore,
+		Offset:  associations.Offset}
+
+	if len(associations.Data) == 0 {
+		return pr, nil
+	}
+
+	ids := make([]int64, len(associations.Data))
+	for index, id := range associations.Data {
+		ids[index] = id.(int64)
+	}
+
+	pr.Data, err = NewObjects(rest, objecttype, model).BatchRead(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
 // Delete - deletes an object association
 func (api *Associations) Delete(fromid int64, toid int64, asstype AssociationType) error {
 	request := map[string]interface{}{
@@ -19,6 +19,9 @@ type ICompanies interface {
 	SearchByDomain(domain string, page *Page, props ...string) (*PageResponse, error)
 	Delete(id int64) error
 	Get(id int64) (interface{}, error)
+	Merge(primaryid int64, mergeid int64) (*MergeResult, error)
+	Contacts(id int64, page *Page, model *Model) (*PageResponse, error)
+	Deals(id int64, page *Page, model *Model) (*PageResponse, error)
 	Query() IQuery
 }
 
@@ -35,21 +38,23 @@ func NewCompanies(rest IRestClient, model *Model) *Companies {
 		model: model}
 }
 
-func (api *Companies) toEntity(response map[string]interface{}) interface{} {
+func (api *Companies) toEntity(response map[string]interface{}) (interface{}, error) {
 	entity := reflect.New(api.model.datatype)
 	entity = entity.Elem()
 
+	var errs ConversionErrors
+
 	if api.model.id != nil {
-		api.model.id.SetValue(response, "companyId", entity)
+		errs.add(api.model.id.SetValue(response, "companyId", entity))
 	}
 
 	if api.model.deleted != nil {
-		api.model.deleted.SetValue(response, "isDeleted", entity)
+		errs.add(api.model.deleted.SetValue(response, "isDeleted", entity))
 	}
 
 	properties, ok := response["properties"].(map[string]interface{})
 	if !ok {
-		return entity.Addr().Interface()
+		return api.model.loaded(entity, errs)
 	}
 
 	for _, prop := range api.model.properties {
@@ -57,32 +62,42 @@ func (api *Companies) toEntity(response map[string]interface{}) interface{} {
 		if !ok {
 			continue
 		}
-		prop.SetValue(property, "value", entity)
+		errs.add(prop.SetValue(property, "value", entity))
 	}
 
-	return entity.Addr().Interface()
+	api.model.setExtra(properties, "value", entity)
+	api.model.setHistory(properties, entity, &errs)
+	return api.model.loaded(entity, errs)
 }
 
 // Create - creates a new company in hubspot
 func (api *Companies) Create(company interface{}) (interface{}, error) {
-	request := createPropertiesRequest(company, "name", api.model)
+	request, err := createPropertiesRequest(company, "name", api.model, writeCreate)
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := api.rest.Post("companies/v2/companies", request)
 	if err != nil {
 		return nil, err
 	}
 
-	return api.toEntity(response), nil
+	return api.toEntity(response)
 }
 
 // Update - updates a company in hubspot
 func (api *Companies) Update(id int64, company interface{}) (interface{}, error) {
-	request := createPropertiesRequest(company, "name", api.model)
+	request, err := createPropertiesRequest(company, "name", api.model, writeUpdate)
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := api.rest.Put(fmt.Sprintf("companies/v2/companies/%d", id), request)
 	if err != nil {
 		return nil, err
 	}
 
-	return api.toEntity(response), nil
+	return api.toEntity(response)
 }
 
 // BatchUpdate - updates multiple companies in hubspot in a single call
@@ -90,7 +105,11 @@ func (api *Companies) BatchUpdate(companies []interface{}) error {
 	var request []interface{}
 
 	for _, company := range companies {
-		companydata := createPropertiesRequest(company, "name", api.model)
+		companydata, err := createPropertiesRequest(company, "name", api.model, writeUpdate)
+		if err != nil {
+			return err
+		}
+
 		companydata["objectId"] = cast.ToInt64(api.model.GetID(company))
 		request = append(request, companydata)
 	}
@@ -139,7 +158,12 @@ func (api *Companies) List(page *Page, props ...string) (*PageResponse, error) {
 				return nil, errors.Errorf("Unexpected response structure from hubspot")
 			}
 
-			pr.Data = append(pr.Data, api.toEntity(contact))
+			entity, err := api.toEntity(contact)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, entity)
 		}
 	}
 
@@ -162,7 +186,12 @@ func (api *Companies) RecentlyModified(page *Page) (*PageResponse, error) {
 	companies, ok := response["results"].([]map[string]interface{})
 	if ok {
 		for _, company := range companies {
-			pr.Data = append(pr.Data, api.toEntity(company))
+			entity, err := api.toEntity(company)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, entity)
 		}
 	}
 
@@ -185,7 +214,12 @@ func (api *Companies) RecentlyCreated(page *Page) (*PageResponse, error) {
 	companies, ok := response["results"].([]map[string]interface{})
 	if ok {
 		for _, company := range companies {
-			pr.Data = append(pr.Data, api.toEntity(company))
+			entity, err := api.toEntity(company)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, entity)
 		}
 	}
 
@@ -228,7 +262,12 @@ func (api *Companies) SearchByDomain(domain string, page *Page, props ...string)
 	companies, ok := response["results"].([]map[string]interface{})
 	if ok {
 		for _, company := range companies {
-			pr.Data = append(pr.Data, api.toEntity(company))
+			entity, err := api.toEntity(company)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, entity)
 		}
 	}
 
@@ -247,7 +286,25 @@ func (api *Companies) Get(id int64) (interface{}, error) {
 		return nil, err
 	}
 
-	return api.toEntity(response), nil
+	return readEntity(api.toEntity(response))
+}
+
+// Merge - merges two companies
+// the company specified by mergeid is absorbed by the primary company
+func (api *Companies) Merge(primaryid int64, mergeid int64) (*MergeResult, error) {
+	return NewObjects(api.rest, "companies", api.model).Merge(primaryid, mergeid)
+}
+
+// Contacts - lists a page of contacts associated to a company
+// the contacts are read using the specified model
+func (api *Companies) Contacts(id int64, page *Page, model *Model) (*PageResponse, error) {
+	return listAssociated(api.rest, id, AssociationCompanyToContact, page, "contacts", model)
+}
+
+// Deals - lists a page of deals associated to a company
+// the deals are read using the specified model
+func (api *Companies) Deals(id int64, page *Page, model *Model) (*PageResponse, error) {
+	return listAssociated(api.rest, id, AssociationCompanyToDeal, page, "deals", model)
 }
 
 // Query - creates a query usable to search for contacts
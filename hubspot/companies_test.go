@@ -113,7 +113,7 @@ type Company struct {
 }
 
 func TestCompanyInterfaceImpl(t *testing.T) {
-	var companies ICompanies = NewCompanies(&TestRest{}, NewModel(reflect.TypeOf(Company{})))
+	var companies ICompanies = NewCompanies(&TestRest{}, MustNewModel(reflect.TypeOf(Company{})))
 
 	// just a noop to make sure go compiler doesn't babble about the var not being used
 	if companies != nil {
@@ -130,7 +130,7 @@ func TestCreateCompany(t *testing.T) {
 			"website":        map[string]interface{}{"value": "www.vertical.de"},
 			"umsatzsteuerid": map[string]interface{}{"value": "123noclue"}}}
 
-	companies := NewCompanies(rest, NewModel(reflect.TypeOf(Company{})))
+	companies := NewCompanies(rest, MustNewModel(reflect.TypeOf(Company{})))
 
 	company := &Company{
 		Name:    "vertical GmbH",
@@ -156,7 +156,7 @@ func TestCreateCompany(t *testing.T) {
 func TestCompanyListDefault(t *testing.T) {
 	rest := &TestRest{Response: readTestResponse(responseCompanyList)}
 
-	companies := NewCompanies(rest, NewModel(reflect.TypeOf(Company{})))
+	companies := NewCompanies(rest, MustNewModel(reflect.TypeOf(Company{})))
 	pageresponse, err := companies.List(nil, "name")
 	require.NoError(t, err)
 
@@ -172,3 +172,36 @@ func TestCompanyListDefault(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "Test Company", company.Name)
 }
+
+func TestCompanyContacts(t *testing.T) {
+	rest := &TestRest{
+		Responses: []map[string]interface{}{
+			readTestResponse(`{"results": [61574, 61575], "hasMore": true, "offset": 61575}`),
+			readTestResponse(`{"results": [
+				{"id": "61574", "properties": {"name": "Peter", "email": "peter@lack.de"}},
+				{"id": "61575", "properties": {"name": "Monika", "email": "monika@left.de"}}]}`)}}
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(Company{})))
+
+	response, err := api.Contacts(1234, NewPage(0, 2), MustNewModel(reflect.TypeOf(Person{})))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(rest.requests))
+	require.Equal(t, "GET crm-associations/v1/associations/1234/HUBSPOT_DEFINED/2?hapikey=xyz&limit=2", rest.requests[0])
+	require.Equal(t, "POST crm/v3/objects/contacts/batch/read?hapikey=xyz", rest.requests[1])
+	require.True(t, response.HasMore)
+	require.Equal(t, int64(61575), response.Offset)
+
+	require.Equal(t, 2, len(response.Data))
+	person := response.Data[0].(*Person)
+	require.Equal(t, int64(61574), person.ID)
+	require.Equal(t, "peter@lack.de", person.EMail)
+}
+
+func TestCompanyDealsWithoutAssociations(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(`{"results": [], "hasMore": false}`)}
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(Company{})))
+
+	response, err := api.Deals(1234, nil, MustNewModel(reflect.TypeOf(Deal{})))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(rest.requests))
+	require.Equal(t, 0, len(response.Data))
+}
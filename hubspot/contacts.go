@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 )
 
@@ -12,12 +13,28 @@ type IContacts interface {
 	CreateOrUpdate(email string, contact interface{}) (int64, error)
 	Update(id int64, contact interface{}) error
 	Delete(id int64) error
+	GDPRDelete(id int64) error
+	GDPRDeleteByEmail(email string) error
 	ListPage(page *Page, props ...string) (*PageResponse, error)
 	GetByID(id int64) (interface{}, error)
 	GetByEmail(email string) (interface{}, error)
+	GetData(id int64) (*ContactData, error)
+	GetDataByEmail(email string) (*ContactData, error)
+	Merge(primaryid int64, mergeid int64) (*MergeResult, error)
+	Companies(id int64, page *Page, model *Model) (*PageResponse, error)
+	Deals(id int64, page *Page, model *Model) (*PageResponse, error)
 	Query() IQuery
 }
 
+// ContactData - all data stored in hubspot for a contact
+type ContactData struct {
+	ID              int64
+	Properties      map[string][]*PropertyVersion // all properties including their history
+	Identities      []string                      // identities of the contact (eg. emails, lead guids)
+	ListMemberships []int64                       // ids of lists the contact is member of
+	FormSubmissions []map[string]interface{}      // form submissions of the contact
+}
+
 // Contacts - hubspot contacts api
 type Contacts struct {
 	model *Model      // model used to serialize / deserialize data
@@ -31,21 +48,23 @@ func NewContacts(rest IRestClient, model *Model) *Contacts {
 		model: model}
 }
 
-func (api *Contacts) toEntity(response map[string]interface{}) interface{} {
+func (api *Contacts) toEntity(response map[string]interface{}) (interface{}, error) {
 	entity := reflect.New(api.model.datatype)
 	entity = entity.Elem()
 
+	var errs ConversionErrors
+
 	if api.model.id != nil {
-		api.model.id.SetValue(response, "vid", entity)
+		errs.add(api.model.id.SetValue(response, "vid", entity))
 	}
 
 	if api.model.deleted != nil {
-		api.model.deleted.SetValue(response, "Deleted", entity)
+		errs.add(api.model.deleted.SetValue(response, "Deleted", entity))
 	}
 
 	properties, ok := response["properties"].(map[string]interface{})
 	if !ok {
-		return entity.Addr().Interface()
+		return api.model.loaded(entity, errs)
 	}
 
 	for _, prop := range api.model.properties {
@@ -53,15 +72,22 @@ func (api *Contacts) toEntity(response map[string]interface{}) interface{} {
 		if !ok {
 			continue
 		}
-		prop.SetValue(property, "value", entity)
+		errs.add(prop.SetValue(property, "value", entity))
 	}
 
-	return entity.Addr().Interface()
+	api.model.setExtra(properties, "value", entity)
+	api.model.setHistory(properties, entity, &errs)
+	return api.model.loaded(entity, errs)
 }
 
 // CreateOrUpdate - creates or updates a contact in hubspot
+// createonly properties are not sent since the contact could already exist
 func (api *Contacts) CreateOrUpdate(email string, contact interface{}) (int64, error) {
-	request := createPropertiesRequest(contact, "property", api.model)
+	request, err := createPropertiesRequest(contact, "property", api.model, writeUpsert)
+	if err != nil {
+		return 0, err
+	}
+
 	response, err := api.rest.Post("contacts/v1/contact/createOrUpdate/email/"+email, request)
 	if err != nil {
 		return 0, err
@@ -72,8 +98,12 @@ func (api *Contacts) CreateOrUpdate(email string, contact interface{}) (int64, e
 
 // Update - updates a contact in hubspot
 func (api *Contacts) Update(id int64, contact interface{}) error {
-	request := createPropertiesRequest(contact, "property", api.model)
-	_, err := api.rest.Post(fmt.Sprintf("contacts/v1/contact/vid/%d/profile", id), request)
+	request, err := createPropertiesRequest(contact, "property", api.model, writeUpdate)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.rest.Post(fmt.Sprintf("contacts/v1/contact/vid/%d/profile", id), request)
 	return err
 }
 
@@ -82,6 +112,26 @@ func (api *Contacts) Delete(id int64) error {
 	return api.rest.Delete(fmt.Sprintf("contacts/v1/contact/vid/%d", id))
 }
 
+func (api *Contacts) gdprDelete(request map[string]interface{}, id interface{}) error {
+	_, err := api.rest.Post("crm/v3/objects/contacts/gdpr-delete", request)
+	return notFound(err, "contact", id)
+}
+
+// GDPRDelete - permanently deletes a contact and all its data to comply with GDPR
+// returns a NotFoundError if the contact doesn't exist (anymore)
+func (api *Contacts) GDPRDelete(id int64) error {
+	return api.gdprDelete(map[string]interface{}{
+		"objectId": cast.ToString(id)}, id)
+}
+
+// GDPRDeleteByEmail - permanently deletes a contact identified by email to comply with GDPR
+// returns a NotFoundError if the contact doesn't exist (anymore)
+func (api *Contacts) GDPRDeleteByEmail(email string) error {
+	return api.gdprDelete(map[string]interface{}{
+		"objectId":   email,
+		"idProperty": "email"}, email)
+}
+
 // GetByID - get a contact by id
 func (api *Contacts) GetByID(id int64) (interface{}, error) {
 	response, err := api.rest.Get(fmt.Sprintf("contacts/v1/contact/vid/%d/profile", id))
@@ -89,7 +139,7 @@ func (api *Contacts) GetByID(id int64) (interface{}, error) {
 		return nil, err
 	}
 
-	return api.toEntity(response), nil
+	return readEntity(api.toEntity(response))
 }
 
 // GetByEmail - get contact information by email
@@ -98,7 +148,83 @@ func (api *Contacts) GetByEmail(email string) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.toEntity(response), nil
+	return readEntity(api.toEntity(response))
+}
+
+func readContactData(response map[string]interface{}) (*ContactData, error) {
+	data := &ContactData{
+		ID:         cast.ToInt64(response["vid"]),
+		Properties: make(map[string][]*PropertyVersion)}
+
+	properties, ok := response["properties"].(map[string]interface{})
+	if ok {
+		for name, propertyobj := range properties {
+			property, ok := propertyobj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			versions, err := readPropertyVersions(property)
+			if err != nil {
+				return nil, err
+			}
+			data.Properties[name] = versions
+		}
+	}
+
+	profiles, _ := response["identity-profiles"].([]interface{})
+	for _, profileobj := range profiles {
+		profile, _ := profileobj.(map[string]interface{})
+		identities, _ := profile["identities"].([]interface{})
+		for _, identityobj := range identities {
+			identity, ok := identityobj.(map[string]interface{})
+			if ok {
+				data.Identities = append(data.Identities, cast.ToString(identity["value"]))
+			}
+		}
+	}
+
+	memberships, _ := response["list-memberships"].([]interface{})
+	for _, membershipobj := range memberships {
+		membership, ok := membershipobj.(map[string]interface{})
+		if ok {
+			data.ListMemberships = append(data.ListMemberships, cast.ToInt64(membership["static-list-id"]))
+		}
+	}
+
+	submissions, _ := response["form-submissions"].([]interface{})
+	for _, submissionobj := range submissions {
+		submission, ok := submissionobj.(map[string]interface{})
+		if ok {
+			data.FormSubmissions = append(data.FormSubmissions, submission)
+		}
+	}
+
+	return data, nil
+}
+
+func (api *Contacts) getData(address string, id interface{}) (*ContactData, error) {
+	response, err := api.rest.Get(address,
+		NewParameter("propertyMode", "value_and_history"),
+		NewParameter("formSubmissionMode", "all"),
+		NewParameter("showListMemberships", "true"))
+	if err != nil {
+		return nil, notFound(err, "contact", id)
+	}
+
+	return readContactData(response)
+}
+
+// GetData - get all data stored for a contact including property history (eg. for subject access requests)
+// returns a NotFoundError if the contact doesn't exist (anymore)
+func (api *Contacts) GetData(id int64) (*ContactData, error) {
+	return api.getData(fmt.Sprintf("contacts/v1/contact/vid/%d/profile", id), id)
+}
+
+// GetDataByEmail - get all data stored for a contact identified by email
+// returns a NotFoundError if the contact doesn't exist (anymore)
+func (api *Contacts) GetDataByEmail(email string) (*ContactData, error) {
+	return api.getData(fmt.Sprintf("contacts/v1/contact/email/%s/profile", email), email)
 }
 
 func (api *Contacts) getListParameters(page *Page, props []string) []*Parameter {
@@ -120,9 +246,8 @@ func (api *Contacts) getListParameters(page *Page, props []string) []*Parameter
 	return parameters
 }
 
-// ListPage - lists a page of contact listing in hubspot
-func (api *Contacts) ListPage(page *Page, props ...string) (*PageResponse, error) {
-	response, err := api.rest.Get("contacts/v1/lists/all/contacts/all", api.getListParameters(page, props)...)
+func (api *Contacts) listContacts(address string, page *Page, props []string) (*PageResponse, error) {
+	response, err := api.rest.Get(address, api.getListParameters(page, props)...)
 	if err != nil {
 		return nil, err
 	}
@@ -133,16 +258,58 @@ func (api *Contacts) ListPage(page *Page, props ...string) (*PageResponse, error
 		pr.Offset = cast.ToInt64(response["vid-offset"])
 	}
 
-	contacts, ok := response["contacts"].([]map[string]interface{})
-	if ok {
+	switch contacts := response["contacts"].(type) {
+	case []map[string]interface{}:
 		for _, contact := range contacts {
-			pr.Data = append(pr.Data, api.toEntity(contact))
+			entity, err := api.toEntity(contact)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, entity)
+		}
+	case []interface{}:
+		for _, contactobj := range contacts {
+			contact, ok := contactobj.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("Unexpected response structure from hubspot")
+			}
+
+			entity, err := api.toEntity(contact)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, entity)
 		}
 	}
 
 	return pr, nil
 }
 
+// ListPage - lists a page of contact listing in hubspot
+func (api *Contacts) ListPage(page *Page, props ...string) (*PageResponse, error) {
+	return api.listContacts("contacts/v1/lists/all/contacts/all", page, props)
+}
+
+// Merge - merges two contacts
+// the contact specified by mergeid is absorbed by the primary contact
+func (api *Contacts) Merge(primaryid int64, mergeid int64) (*MergeResult, error) {
+	return NewObjects(api.rest, "contacts", api.model).Merge(primaryid, mergeid)
+}
+
+// Companies - lists a page of companies associated to a contact
+// the companies are read using the specified model
+func (api *Contacts) Companies(id int64, page *Page, model *Model) (*PageResponse, error) {
+	return listAssociated(api.rest, id, AssociationContactToCompany, page, "companies", model)
+}
+
+// Deals - lists a page of deals associated to a contact
+// the deals are read using the specified model
+func (api *Contacts) Deals(id int64, page *Page, model *Model) (*PageResponse, error) {
+	return listAssociated(api.rest, id, AssociationContactToDeal, page, "deals", model)
+}
+
 // Query - creates a query usable to search for contacts
 func (api *Contacts) Query() IQuery {
 	return &Query{
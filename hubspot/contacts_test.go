@@ -18,7 +18,7 @@ type Person struct {
 }
 
 func TestContactsInterfaceImpl(t *testing.T) {
-	var contacts IContacts = NewContacts(&TestRest{}, NewModel(reflect.TypeOf(Company{})))
+	var contacts IContacts = NewContacts(&TestRest{}, MustNewModel(reflect.TypeOf(Company{})))
 
 	// just a noop to make sure go compiler doesn't babble about the var not being used
 	if contacts != nil {
@@ -30,7 +30,7 @@ func TestCreateOrUpdate(t *testing.T) {
 	rest := &TestRest{}
 	rest.Response = readTestResponse(responseContactCreateOrUpdate)
 
-	contacts := NewContacts(rest, NewModel(reflect.TypeOf(Person{})))
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
 
 	person := &Person{
 		Name:  "Peter",
@@ -43,10 +43,22 @@ func TestCreateOrUpdate(t *testing.T) {
 	require.Equal(t, int64(3234574), created)
 }
 
+func TestCreateOrUpdateSkipsCreateOnly(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseContactCreateOrUpdate)}
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(StagedContact{})))
+
+	// the contact could already exist, so its initial stage must not be overwritten
+	_, err := contacts.CreateOrUpdate("max@example.com", &StagedContact{Email: "max@example.com", Stage: "lead", Score: 12})
+	require.NoError(t, err)
+
+	request := extractRequestProperties("property", rest.LastBody())
+	require.Equal(t, map[string]interface{}{"email": "max@example.com"}, request)
+}
+
 func TestUpdate(t *testing.T) {
 	rest := &TestRest{}
 
-	contacts := NewContacts(rest, NewModel(reflect.TypeOf(Person{})))
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
 
 	person := &Person{
 		ID:    61574,
@@ -67,7 +79,7 @@ func TestUpdate(t *testing.T) {
 func TestDelete(t *testing.T) {
 	rest := &TestRest{}
 
-	contacts := NewContacts(rest, NewModel(reflect.TypeOf(Person{})))
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
 
 	err := contacts.Delete(61574)
 
@@ -84,7 +96,7 @@ func TestGetByID(t *testing.T) {
 			"email":    map[string]interface{}{"value": "peter@lack.de"},
 			"humanage": map[string]interface{}{"value": 28}}}
 
-	contacts := NewContacts(rest, NewModel(reflect.TypeOf(Person{})))
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
 
 	data, err := contacts.GetByID(61574)
 	person := data.(*Person)
@@ -106,7 +118,7 @@ func TestGetByEmail(t *testing.T) {
 			"email":    map[string]interface{}{"value": "peter@lack.de"},
 			"humanage": map[string]interface{}{"value": 28}}}
 
-	contacts := NewContacts(rest, NewModel(reflect.TypeOf(Person{})))
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
 
 	data, err := contacts.GetByEmail("peter@lack.de")
 	person := data.(*Person)
@@ -138,7 +150,7 @@ func TestListDefaultPage(t *testing.T) {
 					"email":    map[string]interface{}{"value": "monika@left.de"},
 					"humanage": map[string]interface{}{"value": 24}}}}}
 
-	contacts := NewContacts(rest, NewModel(reflect.TypeOf(Person{})))
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
 
 	page, err := contacts.ListPage(nil, "name", "email", "humanage")
 
@@ -180,7 +192,7 @@ func TestListCustomPage(t *testing.T) {
 					"email":    map[string]interface{}{"value": "monika@left.de"},
 					"humanage": map[string]interface{}{"value": 24}}}}}
 
-	contacts := NewContacts(rest, NewModel(reflect.TypeOf(Person{})))
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
 
 	page, err := contacts.ListPage(NewPage(int64(544), 54), "name", "email", "humanage")
 
@@ -203,3 +215,109 @@ func TestListCustomPage(t *testing.T) {
 	require.Equal(t, "monika@left.de", person.EMail)
 	require.Equal(t, 24, person.Age)
 }
+
+func TestContactMerge(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "61574",
+		"properties": map[string]interface{}{
+			"name":  "Peter",
+			"email": "peter@lack.de"}}}
+
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	result, err := contacts.Merge(61574, 51157)
+	require.NoError(t, err)
+	require.Equal(t, "POST crm/v3/objects/contacts/merge?hapikey=xyz", rest.requests[0])
+	require.Equal(t, []int64{51157}, result.MergedIDs)
+
+	person := result.Entity.(*Person)
+	require.Equal(t, int64(61574), person.ID)
+	require.Equal(t, "peter@lack.de", person.EMail)
+}
+
+const responseContactData string = `{
+	"vid": 61574,
+	"properties": {
+	  "email": {
+		"value": "peter@lack.de",
+		"versions": [
+		  {
+			"value": "peter@lack.de",
+			"source-type": "API",
+			"source": "API",
+			"sourceId": "integration",
+			"timestamp": 1484026585538
+		  },
+		  {
+			"value": "peter@old.de",
+			"source": "CRM_UI",
+			"sourceId": "userid:123",
+			"timestamp": 1484026000000
+		  }
+		]
+	  }
+	},
+	"identity-profiles": [
+	  {
+		"vid": 61574,
+		"identities": [
+		  {"type": "EMAIL", "value": "peter@lack.de"},
+		  {"type": "LEAD_GUID", "value": "f9e3b1f4-8e5a-4c0d-8b36-7e2fa2a3b3c1"}
+		]
+	  }
+	],
+	"list-memberships": [
+	  {"static-list-id": 226, "internal-list-id": 2147483643, "is-member": true}
+	],
+	"form-submissions": [
+	  {"form-id": "5b5a1d58-0b3a-4b2d-b6d0-4b7f0d9f2d51", "timestamp": 1484026585538}
+	]
+  }`
+
+func TestContactGDPRDelete(t *testing.T) {
+	rest := &TestRest{}
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	err := contacts.GDPRDelete(61574)
+	require.NoError(t, err)
+	require.Equal(t, "POST crm/v3/objects/contacts/gdpr-delete?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, map[string]interface{}{"objectId": "61574"}, rest.LastBody())
+
+	err = contacts.GDPRDeleteByEmail("peter@lack.de")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"objectId": "peter@lack.de", "idProperty": "email"}, rest.LastBody())
+}
+
+func TestContactGDPRDeleteNotFound(t *testing.T) {
+	rest := &TestRest{Error: &RestError{StatusCode: 404, Status: "404 Not Found"}}
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	err := contacts.GDPRDelete(61574)
+	require.Error(t, err)
+	require.True(t, IsNotFound(err))
+
+	notfound, ok := err.(*NotFoundError)
+	require.True(t, ok)
+	require.Equal(t, "contact", notfound.ObjectType)
+	require.Equal(t, "61574", notfound.ID)
+}
+
+func TestContactGetData(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseContactData)}
+	contacts := NewContacts(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	data, err := contacts.GetData(61574)
+	require.NoError(t, err)
+	require.Equal(t, "GET contacts/v1/contact/vid/61574/profile?hapikey=xyz&propertyMode=value_and_history&formSubmissionMode=all&showListMemberships=true", rest.LastRequest())
+
+	require.Equal(t, int64(61574), data.ID)
+	require.Equal(t, 2, len(data.Properties["email"]))
+	require.Equal(t, "peter@lack.de", data.Properties["email"][0].Value)
+	require.Equal(t, "API", data.Properties["email"][0].Source)
+	require.Equal(t, "peter@old.de", data.Properties["email"][1].Value)
+	require.Equal(t, "userid:123", data.Properties["email"][1].SourceID)
+	require.Equal(t, 2017, data.Properties["email"][1].Timestamp.Year())
+	require.Equal(t, []string{"peter@lack.de", "f9e3b1f4-8e5a-4c0d-8b36-7e2fa2a3b3c1"}, data.Identities)
+	require.Equal(t, []int64{226}, data.ListMemberships)
+	require.Equal(t, 1, len(data.FormSubmissions))
+}
@@ -1,79 +1,331 @@
 package hubspot
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"reflect"
+	"strings"
 	"time"
 	"unicode"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 )
 
 var timetype reflect.Type = reflect.TypeOf(time.Time{})
+var nulltimetype reflect.Type = reflect.TypeOf(sql.NullTime{})
+var valuertype reflect.Type = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+var scannertype reflect.Type = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
 
-func convert(value interface{}, t reflect.Type) interface{} {
+// convert - converts a value sent by hubspot to a type
+// timestamps are converted to the specified location. Returns an error if the value can't be converted
+func convert(value interface{}, t reflect.Type, location *time.Location) (interface{}, error) {
 	if reflect.TypeOf(value) == t {
-		return value
+		return value, nil
 	}
 
 	if t == timetype {
 		switch v := value.(type) {
 		case string:
-			if len(v) >= 0 {
-				alldigits := true
-				for _, char := range v {
-					if !unicode.IsDigit(char) {
-						alldigits = false
-						break
-					}
-				}
-
-				if alldigits {
-					// hubspot sends unix time in milliseconds
-					return cast.ToTime(cast.ToInt64(v) / 1000).UTC()
-				}
-			}
+			return parseTime(v, location)
+		case float64:
+			// numbers in json responses are timestamps in milliseconds
+			return parseTime(cast.ToString(int64(v)), location)
 		}
-		return cast.ToTime(value)
+		return cast.ToTimeE(value)
 	}
 
 	switch t.Kind() {
 	case reflect.Bool:
-		return cast.ToBool(value)
+		return cast.ToBoolE(value)
 	case reflect.Int:
-		return cast.ToInt(value)
+		return cast.ToIntE(value)
 	case reflect.Int8:
-		return cast.ToInt8(value)
+		return cast.ToInt8E(value)
 	case reflect.Int16:
-		return cast.ToInt16(value)
+		return cast.ToInt16E(value)
 	case reflect.Int32:
-		return cast.ToInt32(value)
+		return cast.ToInt32E(value)
 	case reflect.Int64:
-		return cast.ToInt64(value)
+		return cast.ToInt64E(value)
 	case reflect.Uint:
-		return cast.ToUint(value)
+		return cast.ToUintE(value)
 	case reflect.Uint8:
-		return cast.ToUint8(value)
+		return cast.ToUint8E(value)
 	case reflect.Uint16:
-		return cast.ToUint16(value)
+		return cast.ToUint16E(value)
 	case reflect.Uint32:
-		return cast.ToUint32(value)
+		return cast.ToUint32E(value)
 	case reflect.Uint64:
-		return cast.ToUint64(value)
+		return cast.ToUint64E(value)
 	case reflect.Float32:
-		return cast.ToFloat32(value)
+		return cast.ToFloat32E(value)
 	case reflect.Float64:
-		return cast.ToFloat64(value)
+		return cast.ToFloat64E(value)
 	case reflect.String:
-		return cast.ToString(value)
+		return cast.ToStringE(value)
 	case reflect.Slice:
+		var items []interface{}
+		switch v := value.(type) {
+		case string:
+			items = splitMultiValue(v)
+		default:
+			sourcevalue := reflect.ValueOf(value)
+			if sourcevalue.Kind() != reflect.Slice {
+				return nil, errors.Errorf("Unable to convert %#v of type %T to %s", value, value, t)
+			}
+
+			for i := 0; i < sourcevalue.Len(); i++ {
+				items = append(items, sourcevalue.Index(i).Interface())
+			}
+		}
+
 		elementtype := t.Elem()
-		array := reflect.MakeSlice(t, 0, 8)
-		sourcevalue := reflect.ValueOf(value)
-		for i := 0; i < sourcevalue.Len(); i++ {
-			array = reflect.Append(array, reflect.ValueOf(convert(sourcevalue.Index(i).Interface(), elementtype)))
+		array := reflect.MakeSlice(t, 0, len(items))
+		for _, item := range items {
+			converted, err := convert(item, elementtype, location)
+			if err != nil {
+				return nil, err
+			}
+			array = reflect.Append(array, toType(reflect.ValueOf(converted), elementtype))
+		}
+		return array.Interface(), nil
+	}
+
+	return nil, errors.Errorf("Conversion to %s is not supported", t)
+}
+
+// multiValueSeparator - separator of values of multiple checkbox properties
+const multiValueSeparator = ";"
+
+// splitMultiValue - splits the value of a multiple checkbox property
+func splitMultiValue(value string) []interface{} {
+	var items []interface{}
+	for _, item := range strings.Split(value, multiValueSeparator) {
+		if len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// joinMultiValue - joins values of a string slice to the value of a multiple checkbox property
+func joinMultiValue(value reflect.Value) string {
+	items := make([]string, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		items[i] = value.Index(i).String()
+	}
+	return strings.Join(items, multiValueSeparator)
+}
+
+// toType - converts a value to a named type with the same underlying kind (eg. string to a string enum)
+func toType(value reflect.Value, t reflect.Type) reflect.Value {
+	if value.Type() != t && value.Kind() == t.Kind() && value.Type().ConvertibleTo(t) {
+		return value.Convert(t)
+	}
+	return value
+}
+
+// isDigits - determines whether a string only consists of digits
+func isDigits(value string) bool {
+	for _, char := range value {
+		if !unicode.IsDigit(char) {
+			return false
+		}
+	}
+	return len(value) > 0
+}
+
+// parseTime - parses a timestamp sent by hubspot
+// v1 apis send unix time in milliseconds, v3 apis send ISO-8601 strings.
+// Dates without time are parsed as midnight UTC, timestamps are converted to the location.
+func parseTime(value string, location *time.Location) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	if isDigits(value) {
+		return time.Unix(0, cast.ToInt64(value)*int64(time.Millisecond)).In(location), nil
+	}
+
+	parsed, err := time.Parse("2006-01-02", value)
+	if err == nil {
+		return parsed, nil
+	}
+
+	parsed, err = time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		return parsed.In(location), nil
+	}
+
+	return cast.ToTimeE(value)
+}
+
+// toInt64Slice - converts a json array to a slice of int64 values
+func toInt64Slice(value interface{}) []int64 {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]int64, 0, len(items))
+	for _, item := range items {
+		result = append(result, cast.ToInt64(item))
+	}
+	return result
+}
+
+// toTime - converts a timestamp sent by hubspot to a time in UTC
+// returns the zero time if no timestamp was sent and an error if the timestamp can't be converted
+func toTime(value interface{}) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, nil
+	}
+
+	converted, err := convert(value, timetype, time.UTC)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "Invalid timestamp '%v'", value)
+	}
+
+	return converted.(time.Time), nil
+}
+
+// readTimestamps - reads the creation and modification time of an object in a hubspot response
+func readTimestamps(response map[string]interface{}, createdname string, updatedname string) (time.Time, time.Time, error) {
+	createdat, err := toTime(response[createdname])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	updatedat, err := toTime(response[updatedname])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return createdat, updatedat, nil
+}
+
+// toHubspotDate - get unix time in milliseconds of midnight UTC of the date of a time
+// hubspot only accepts midnight UTC for date properties
+func toHubspotDate(value time.Time) string {
+	return toHubspotString(DateOf(value).Time())
+}
+
+// toHubspotString - converts a value to the string representation used by hubspot in forms and files
+func toHubspotString(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		// hubspot expects unix time in milliseconds
+		return cast.ToString(v.UnixNano() / int64(time.Millisecond))
+	}
+
+	return cast.ToString(value)
+}
+
+// isNullValue - determines whether a value sent by hubspot represents no value for a type
+// hubspot sends empty strings for properties without value
+func isNullValue(value interface{}, t reflect.Type) bool {
+	return value == nil || (value == "" && t.Kind() != reflect.String)
+}
+
+// setFieldValue - sets a value sent by hubspot to a struct field
+// pointer fields are set to nil and nullable types (sql.Scanner) are set to null if no value was sent.
+// The field is not changed if the value can't be converted.
+func setFieldValue(field reflect.Value, value interface{}, location *time.Location) error {
+	if value != nil {
+		handled, err := unmarshalField(field, value)
+		if handled {
+			return err
+		}
+	}
+
+	if field.CanAddr() {
+		scanner, ok := field.Addr().Interface().(sql.Scanner)
+		if ok {
+			switch {
+			case isNullValue(value, field.Type()):
+				return scanner.Scan(nil)
+			case field.Type() == nulltimetype:
+				converted, err := convert(value, timetype, location)
+				if err != nil {
+					return err
+				}
+				return scanner.Scan(converted)
+			default:
+				return scanner.Scan(value)
+			}
+		}
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if isNullValue(value, field.Type().Elem()) {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+
+		target := reflect.New(field.Type().Elem())
+		err := setFieldValue(target.Elem(), value, location)
+		if err != nil {
+			return err
 		}
-		return array.Interface()
+
+		field.Set(target)
+		return nil
+	}
+
+	if isNullValue(value, field.Type()) {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
 	}
 
+	converted, err := convert(value, field.Type(), location)
+	if err != nil {
+		return err
+	}
+
+	field.Set(toType(reflect.ValueOf(converted), field.Type()))
 	return nil
 }
+
+// getNullableValue - get the value of a pointer field or a nullable type (driver.Valuer)
+// fields with custom conversions (see RegisterConverter) are converted to their hubspot value
+//
+// **Returns**
+//   value   : value of the field (dereferenced for pointers)
+//   isnil   : whether the field contains no value
+//   explicit: whether the field is a pointer or nullable type which always sends its value
+//   err     : error if a custom conversion failed
+func getNullableValue(field reflect.Value) (interface{}, bool, bool, error) {
+	explicit := false
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, true, true, nil
+		}
+
+		field = field.Elem()
+		explicit = true
+	}
+
+	marshaled, ok, err := marshalField(field)
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	if ok {
+		return marshaled, false, explicit, nil
+	}
+
+	valuer, ok := field.Interface().(driver.Valuer)
+	if ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return nil, false, false, err
+		}
+		return value, value == nil, true, nil
+	}
+
+	return field.Interface(), false, explicit, nil
+}
@@ -0,0 +1,102 @@
+package hubspot
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+var datetype reflect.Type = reflect.TypeOf(Date{})
+
+// Date - civil date without time of day and location
+// used for hubspot date properties which are stored as midnight UTC
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate - creates a new date
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf - get the date of a time in the location of the time
+func DateOf(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate - parses a date in the format used by hubspot
+// supports unix time in milliseconds, ISO-8601 dates (2006-01-02) and ISO-8601 timestamps
+func ParseDate(value string) (Date, error) {
+	if len(value) == 0 {
+		return Date{}, nil
+	}
+
+	if isDigits(value) {
+		return DateOf(time.Unix(0, cast.ToInt64(value)*int64(time.Millisecond)).UTC()), nil
+	}
+
+	parsed, err := time.Parse("2006-01-02", value)
+	if err == nil {
+		return DateOf(parsed), nil
+	}
+
+	parsed, err = time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		return DateOf(parsed.UTC()), nil
+	}
+
+	return Date{}, errors.Errorf("'%s' is no valid date", value)
+}
+
+// IsZero - determines whether the date is not set
+func (date Date) IsZero() bool {
+	return date == Date{}
+}
+
+// Time - get the time at midnight UTC of the date
+func (date Date) Time() time.Time {
+	if date.IsZero() {
+		return time.Time{}
+	}
+	return time.Date(date.Year, date.Month, date.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// String - get the ISO-8601 representation of the date
+func (date Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", date.Year, date.Month, date.Day)
+}
+
+// MarshalHubspot - converts the date to unix time in milliseconds at midnight UTC
+func (date Date) MarshalHubspot() (string, error) {
+	return toHubspotString(date.Time()), nil
+}
+
+// UnmarshalHubspot - reads a date from a hubspot property value
+func (date *Date) UnmarshalHubspot(value string) error {
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+
+	*date = parsed
+	return nil
+}
+
+// isDateType - determines whether a type can be used for date and datetime properties
+func isDateType(datatype reflect.Type) bool {
+	if datatype.Kind() == reflect.Ptr {
+		datatype = datatype.Elem()
+	}
+
+	return datatype == timetype || datatype == nulltimetype || datatype == datetype
+}
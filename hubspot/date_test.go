@@ -0,0 +1,109 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type DatedDeal struct {
+	ID        int64     `hubspot:"id"`
+	CloseDate time.Time `hubspot:"name=closedate,date"`
+	Contacted time.Time `hubspot:"name=notes_last_contacted,datetime"`
+	Started   Date      `hubspot:"name=startdate"`
+	Renewal   *Date     `hubspot:"name=renewaldate"`
+}
+
+func TestDateWrite(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "512"}}
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(DatedDeal{})))
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	renewal := NewDate(2021, 4, 1)
+	_, err = api.Create(&DatedDeal{
+		CloseDate: time.Date(2020, 4, 1, 0, 30, 0, 0, berlin),
+		Contacted: time.Date(2020, 4, 1, 10, 0, 0, 500000000, time.UTC),
+		Started:   NewDate(2020, 3, 1),
+		Renewal:   &renewal})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"closedate":            "1585699200000",
+		"notes_last_contacted": "1585735200500",
+		"startdate":            "1583020800000",
+		"renewaldate":          "1617235200000"}, request)
+}
+
+func TestDateReadV3(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(`{
+		"id": "512",
+		"properties": {
+		  "closedate": "2020-04-01T00:00:00Z",
+		  "notes_last_contacted": "2020-04-01T10:00:00.500Z",
+		  "startdate": "2020-03-01",
+		  "renewaldate": ""
+		}
+	  }`)}
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(DatedDeal{})))
+
+	entity, err := api.Get(512)
+	require.NoError(t, err)
+
+	deal := entity.(*DatedDeal)
+	require.Equal(t, time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), deal.CloseDate)
+	require.Equal(t, time.Date(2020, 4, 1, 10, 0, 0, 500000000, time.UTC), deal.Contacted)
+	require.Equal(t, NewDate(2020, 3, 1), deal.Started)
+	require.Nil(t, deal.Renewal)
+}
+
+func TestDateReadPortalLocation(t *testing.T) {
+	newyork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	model := MustNewModelWithOptions(reflect.TypeOf(DatedDeal{}), Options{Location: newyork})
+	result, err := objectToEntity(map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"closedate":            "1585699200000",
+			"notes_last_contacted": "1585699200000"}}, model)
+	require.NoError(t, err)
+	entity := result.(*DatedDeal)
+
+	// dates must not move to the previous day when converted to the portal location
+	require.Equal(t, 1, entity.CloseDate.Day())
+	require.Equal(t, time.UTC, entity.CloseDate.Location())
+	require.Equal(t, 31, entity.Contacted.Day())
+	require.Equal(t, newyork, entity.Contacted.Location())
+}
+
+func TestParseDate(t *testing.T) {
+	date, err := ParseDate("1585699200000")
+	require.NoError(t, err)
+	require.Equal(t, "2020-04-01", date.String())
+
+	date, err = ParseDate("2020-04-01T10:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, NewDate(2020, 4, 1), date)
+
+	date, err = ParseDate("")
+	require.NoError(t, err)
+	require.True(t, date.IsZero())
+	require.True(t, date.Time().IsZero())
+
+	_, err = ParseDate("first of april")
+	require.Error(t, err)
+}
+
+func TestDateTagOnInvalidType(t *testing.T) {
+	type InvalidDate struct {
+		Name string `hubspot:"date"`
+	}
+
+	_, err := NewModel(reflect.TypeOf(InvalidDate{}))
+	require.Error(t, err)
+}
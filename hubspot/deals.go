@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 )
 
@@ -18,6 +19,9 @@ type IDeals interface {
 	RecentlyCreated(page *Page, since *time.Time, includeassociations bool) (*PageResponse, error)
 	Delete(id int64) error
 	Get(id int64) (interface{}, error)
+	Merge(primaryid int64, mergeid int64) (*MergeResult, error)
+	Contacts(id int64, page *Page, model *Model) (*PageResponse, error)
+	Companies(id int64, page *Page, model *Model) (*PageResponse, error)
 	Query() IQuery
 }
 
@@ -34,34 +38,36 @@ func NewDeals(rest IRestClient, model *Model) *Deals {
 		model: model}
 }
 
-func (api *Deals) toEntity(response map[string]interface{}) interface{} {
+func (api *Deals) toEntity(response map[string]interface{}) (interface{}, error) {
 	entity := reflect.New(api.model.datatype)
 	entity = entity.Elem()
 
+	var errs ConversionErrors
+
 	if api.model.id != nil {
-		api.model.id.SetValue(response, "dealId", entity)
+		errs.add(api.model.id.SetValue(response, "dealId", entity))
 	}
 
 	if api.model.deleted != nil {
-		api.model.deleted.SetValue(response, "isDeleted", entity)
+		errs.add(api.model.deleted.SetValue(response, "isDeleted", entity))
 	}
 
 	if api.model.companies != nil || api.model.contacts != nil {
 		associations, ok := response["associations"].(map[string]interface{})
 		if ok {
 			if api.model.companies != nil {
-				api.model.companies.SetValue(associations, "associatedCompanyIds", entity)
+				errs.add(api.model.companies.SetValue(associations, "associatedCompanyIds", entity))
 			}
 
 			if api.model.contacts != nil {
-				api.model.contacts.SetValue(associations, "associatedVids", entity)
+				errs.add(api.model.contacts.SetValue(associations, "associatedVids", entity))
 			}
 		}
 	}
 
 	properties, ok := response["properties"].(map[string]interface{})
 	if !ok {
-		return entity.Addr().Interface()
+		return api.model.loaded(entity, errs)
 	}
 
 	for _, prop := range api.model.properties {
@@ -70,10 +76,12 @@ func (api *Deals) toEntity(response map[string]interface{}) interface{} {
 			continue
 		}
 
-		prop.SetValue(property, "value", entity)
+		errs.add(prop.SetValue(property, "value", entity))
 	}
 
-	return entity.Addr().Interface()
+	api.model.setExtra(properties, "value", entity)
+	api.model.setHistory(properties, entity, &errs)
+	return api.model.loaded(entity, errs)
 }
 
 // Create - creates a deal in hubspot
@@ -88,24 +96,32 @@ func (api *Deals) Create(deal interface{}) (interface{}, error) {
 		request["associations"] = associatons
 	}
 
-	request["properties"] = getProperties(deal, "name", api.model)
+	properties, err := getProperties(deal, "name", api.model, writeCreate)
+	if err != nil {
+		return nil, err
+	}
+	request["properties"] = properties
 
 	response, err := api.rest.Post("deals/v1/deal", request)
 	if err != nil {
 		return nil, err
 	}
 
-	return api.toEntity(response), nil
+	return api.toEntity(response)
 }
 
 // Update - updates data of a deal
 func (api *Deals) Update(id int64, deal interface{}) (interface{}, error) {
-	request := createPropertiesRequest(deal, "name", api.model)
+	request, err := createPropertiesRequest(deal, "name", api.model, writeUpdate)
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := api.rest.Put(fmt.Sprintf("deals/v1/deal/%d", id), request)
 	if err != nil {
 		return nil, err
 	}
-	return api.toEntity(response), nil
+	return api.toEntity(response)
 }
 
 // UpdateBulk - updates multiple deal entries in hubspot
@@ -113,7 +129,11 @@ func (api *Deals) UpdateBulk(deals []interface{}) error {
 	var request []interface{}
 
 	for _, deal := range deals {
-		dealdata := createPropertiesRequest(deal, "name", api.model)
+		dealdata, err := createPropertiesRequest(deal, "name", api.model, writeUpdate)
+		if err != nil {
+			return err
+		}
+
 		dealdata["objectId"] = cast.ToInt64(api.model.GetID(deal))
 		request = append(request, dealdata)
 	}
@@ -144,7 +164,7 @@ func (api *Deals) getListParameters(page *Page, countproperty string, includeass
 	return parameters
 }
 
-func (api *Deals) convertListResponse(response map[string]interface{}) *PageResponse {
+func (api *Deals) convertListResponse(response map[string]interface{}) (*PageResponse, error) {
 	pr := new(PageResponse)
 	pr.HasMore = cast.ToBool(response["hasMore"])
 	if pr.HasMore {
@@ -157,14 +177,19 @@ func (api *Deals) convertListResponse(response map[string]interface{}) *PageResp
 		for _, dealobj := range deals {
 			deal, ok := dealobj.(map[string]interface{})
 			if !ok {
-				return nil
+				return nil, errors.Errorf("Unexpected response structure from hubspot")
+			}
+
+			entity, err := api.toEntity(deal)
+			if err != nil {
+				return nil, err
 			}
 
-			pr.Data = append(pr.Data, api.toEntity(deal))
+			pr.Data = append(pr.Data, entity)
 		}
 	}
 
-	return pr
+	return pr, nil
 }
 
 // List - lists a page of deals from hubspot
@@ -174,7 +199,7 @@ func (api *Deals) List(page *Page, includeassociations bool, props ...string) (*
 		return nil, err
 	}
 
-	return api.convertListResponse(response), nil
+	return api.convertListResponse(response)
 }
 
 // RecentlyModified - lists a page of recently modified deals
@@ -184,7 +209,7 @@ func (api *Deals) RecentlyModified(page *Page, since *time.Time, includeassociat
 		return nil, err
 	}
 
-	return api.convertListResponse(response), nil
+	return api.convertListResponse(response)
 }
 
 // RecentlyCreated - lists a page of recently created deals
@@ -194,7 +219,7 @@ func (api *Deals) RecentlyCreated(page *Page, since *time.Time, includeassociati
 		return nil, err
 	}
 
-	return api.convertListResponse(response), nil
+	return api.convertListResponse(response)
 }
 
 // Delete - delete a deal in hubspot
@@ -208,7 +233,25 @@ func (api *Deals) Get(id int64) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.toEntity(response), nil
+	return readEntity(api.toEntity(response))
+}
+
+// Merge - merges two deals
+// the deal specified by mergeid is absorbed by the primary deal
+func (api *Deals) Merge(primaryid int64, mergeid int64) (*MergeResult, error) {
+	return NewObjects(api.rest, "deals", api.model).Merge(primaryid, mergeid)
+}
+
+// Contacts - lists a page of contacts associated to a deal
+// the contacts are read using the specified model
+func (api *Deals) Contacts(id int64, page *Page, model *Model) (*PageResponse, error) {
+	return listAssociated(api.rest, id, AssociationDealToContact, page, "contacts", model)
+}
+
+// Companies - lists a page of companies associated to a deal
+// the companies are read using the specified model
+func (api *Deals) Companies(id int64, page *Page, model *Model) (*PageResponse, error) {
+	return listAssociated(api.rest, id, AssociationDealToCompany, page, "companies", model)
 }
 
 // Query - searches for deals by criterias
@@ -206,7 +206,7 @@ type Deal struct {
 
 func TestDealCreate(t *testing.T) {
 	rest := &TestRest{Response: readTestResponse(responseDealCreate)}
-	api := NewDeals(rest, NewModel(reflect.TypeOf(Deal{})))
+	api := NewDeals(rest, MustNewModel(reflect.TypeOf(Deal{})))
 	dealresponse, err := api.Create(&Deal{
 		Name:      "TestDeal",
 		Stage:     "closedwon",
@@ -233,7 +233,7 @@ func TestDealCreate(t *testing.T) {
 
 func TestDealQuery(t *testing.T) {
 	rest := &TestRest{Response: readTestResponse(responseDealQuery)}
-	api := NewDeals(rest, NewModel(reflect.TypeOf(Deal{})))
+	api := NewDeals(rest, MustNewModel(reflect.TypeOf(Deal{})))
 
 	query := api.Query()
 	query.Where(Equals("dealname", "vertical GmbH (Lukass Maceks)"))
@@ -265,3 +265,18 @@ func TestDealQuery(t *testing.T) {
 	require.Equal(t, 3, deal.CloseDate.Minute())
 	require.Equal(t, 59, deal.CloseDate.Second())
 }
+
+func TestDealCompanies(t *testing.T) {
+	rest := &TestRest{
+		Responses: []map[string]interface{}{
+			readTestResponse(`{"results": [4321], "hasMore": false}`),
+			readTestResponse(`{"results": [{"id": "4321", "properties": {"name": "Vertical GmbH"}}]}`)}}
+	api := NewDeals(rest, MustNewModel(reflect.TypeOf(Deal{})))
+
+	response, err := api.Companies(1234, nil, MustNewModel(reflect.TypeOf(Company{})))
+	require.NoError(t, err)
+	require.Equal(t, "GET crm-associations/v1/associations/1234/HUBSPOT_DEFINED/5?hapikey=xyz", rest.requests[0])
+	require.Equal(t, "POST crm/v3/objects/companies/batch/read?hapikey=xyz", rest.requests[1])
+	require.False(t, response.HasMore)
+	require.Equal(t, "Vertical GmbH", response.Data[0].(*Company).Name)
+}
@@ -0,0 +1,95 @@
+package hubspot
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var enumtype reflect.Type = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// Enum - string based type with a fixed set of values
+// used for enumeration properties (select, radio, multiple checkboxes) to validate values
+//   type Stage string
+//   func (Stage) Options() []string {
+//       return []string{"appointmentscheduled", "closedwon", "closedlost"}
+//   }
+type Enum interface {
+	Options() []string
+}
+
+// getEnumOptions - get the options of an enum type or the element type of an enum slice
+// returns nil if the type is no enum
+func getEnumOptions(datatype reflect.Type) []string {
+	for datatype.Kind() == reflect.Ptr || datatype.Kind() == reflect.Slice {
+		datatype = datatype.Elem()
+	}
+
+	if !datatype.Implements(enumtype) {
+		return nil
+	}
+
+	return reflect.Zero(datatype).Interface().(Enum).Options()
+}
+
+// getOptionValues - get the string values contained in an enumeration field
+func getOptionValues(field reflect.Value) []string {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		if field.Len() == 0 {
+			return nil
+		}
+		return []string{field.String()}
+	case reflect.Slice:
+		var values []string
+		for i := 0; i < field.Len(); i++ {
+			item := field.Index(i)
+			if item.Kind() == reflect.String {
+				values = append(values, item.String())
+			}
+		}
+		return values
+	}
+
+	return nil
+}
+
+// Validate - validates values of enumeration properties of an entity
+// returns an error for the first value which is not an option of its property
+func (mdl *Model) Validate(entity interface{}) error {
+	refvalue := reflect.Indirect(reflect.ValueOf(entity))
+	for _, prop := range mdl.fieldorder {
+		if len(prop.Options) == 0 {
+			continue
+		}
+
+		field := prop.field(refvalue, false)
+		if !field.IsValid() {
+			continue
+		}
+
+		for _, value := range getOptionValues(field) {
+			if !prop.isOption(value) {
+				return errors.Errorf("Value '%s' of field '%s' is no option of property '%s'", value, prop.StructField, prop.HubspotName)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (prop *ModelProperty) isOption(value string) bool {
+	for _, option := range prop.Options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Channel string
+
+func (Channel) Options() []string {
+	return []string{"email", "phone", "mail"}
+}
+
+type ChannelContact struct {
+	ID        int64     `hubspot:"id"`
+	Interests []string  `hubspot:"name=interests"`
+	Channels  []Channel `hubspot:"name=preferred_channels"`
+	Primary   Channel   `hubspot:"name=primary_channel"`
+	Region    string    `hubspot:"options=emea|apac|amer"`
+}
+
+func TestMultiValueRead(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(ChannelContact{}))
+	result, err := objectToEntity(map[string]interface{}{
+		"id": "61574",
+		"properties": map[string]interface{}{
+			"interests":          "golf;sailing;",
+			"preferred_channels": "email;phone",
+			"primary_channel":    "phone",
+			"region":             "emea"}}, model)
+	require.NoError(t, err)
+	contact := result.(*ChannelContact)
+
+	require.Equal(t, []string{"golf", "sailing"}, contact.Interests)
+	require.Equal(t, []Channel{"email", "phone"}, contact.Channels)
+	require.Equal(t, Channel("phone"), contact.Primary)
+	require.Equal(t, "emea", contact.Region)
+}
+
+func TestMultiValueWrite(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "61574"}}
+	api := NewObjects(rest, "contacts", MustNewModel(reflect.TypeOf(ChannelContact{})))
+
+	_, err := api.Update(61574, &ChannelContact{
+		Interests: []string{},
+		Channels:  []Channel{"email", "mail"}})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"interests":          "",
+		"preferred_channels": "email;mail"}, request)
+}
+
+func TestModelValidate(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(ChannelContact{}))
+	require.Equal(t, []string{"email", "phone", "mail"}, model.GetProperty("Channels").Options)
+	require.Equal(t, []string{"emea", "apac", "amer"}, model.GetProperty("Region").Options)
+
+	require.NoError(t, model.Validate(&ChannelContact{Channels: []Channel{"email"}, Primary: "mail", Region: "apac"}))
+	require.NoError(t, model.Validate(&ChannelContact{}))
+	require.Error(t, model.Validate(&ChannelContact{Channels: []Channel{"email", "fax"}}))
+	require.Error(t, model.Validate(ChannelContact{Primary: "pigeon"}))
+	require.Error(t, model.Validate(&ChannelContact{Region: "mars"}))
+}
@@ -0,0 +1,92 @@
+package hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// NotFoundError - error returned when an object doesn't exist (anymore) in hubspot
+type NotFoundError struct {
+	ObjectType string // type of object which was not found
+	ID         string // id or email used to identify the object
+}
+
+// Error - get error message
+func (err *NotFoundError) Error() string {
+	return fmt.Sprintf("%s '%s' not found", err.ObjectType, err.ID)
+}
+
+// IsNotFound - determines whether an error indicates that an object was not found in hubspot
+func IsNotFound(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *NotFoundError:
+		return true
+	case *RestError:
+		return cause.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
+
+// notFound - converts a not found response of hubspot to a NotFoundError
+// other errors are returned unchanged
+func notFound(err error, objecttype string, id interface{}) error {
+	if IsNotFound(err) {
+		return &NotFoundError{
+			ObjectType: objecttype,
+			ID:         fmt.Sprintf("%v", id)}
+	}
+
+	return err
+}
+
+// ConversionError - error returned when a value sent by hubspot can't be converted to the type of a field
+// or the value of a field can't be converted to a property value sent to hubspot
+type ConversionError struct {
+	Field    string      // name of the struct field
+	Property string      // name of the hubspot property
+	Value    interface{} // value sent by hubspot or value of the field
+	Err      error       // error which occurred converting the value
+}
+
+// Error - get error message
+func (err *ConversionError) Error() string {
+	return fmt.Sprintf("unable to convert value '%v' between property '%s' and field '%s': %s", err.Value, err.Property, err.Field, err.Err)
+}
+
+// Cause - get error which occurred converting the value
+func (err *ConversionError) Cause() error {
+	return err.Err
+}
+
+// ConversionErrors - errors which occurred converting the properties of an entity
+// returned by models using the Strict option (see Options). Requests which created, changed or merged an object
+// return the entity along with the errors since the object already exists in hubspot.
+type ConversionErrors []*ConversionError
+
+// Error - get error message
+func (errs ConversionErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// add - adds an error returned by ModelProperty.SetValue
+func (errs *ConversionErrors) add(err error) {
+	if err == nil {
+		return
+	}
+
+	conversion, ok := err.(*ConversionError)
+	if !ok {
+		conversion = &ConversionError{Err: err}
+	}
+
+	*errs = append(*errs, conversion)
+}
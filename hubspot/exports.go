@@ -0,0 +1,318 @@
+package hubspot
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// object types used by the exports api
+const (
+	ExportObjectContact = "CONTACT"
+	ExportObjectCompany = "COMPANY"
+	ExportObjectDeal    = "DEAL"
+	ExportObjectTicket  = "TICKET"
+)
+
+// header of the column containing object ids in exported files
+var exportIDColumns = map[string]bool{
+	"Record ID":    true,
+	"hs_object_id": true}
+
+// ExportRequest - request to export crm objects
+type ExportRequest struct {
+	Name       string    // name of export shown in hubspot
+	ObjectType string    // type of objects to export (eg. CONTACT, COMPANY)
+	Properties []string  // properties to export
+	Filters    []*Filter // filters objects to export (all objects are exported if empty)
+	Language   string    // language of exported values (EN if not specified)
+}
+
+// ExportStatus - status of an export
+type ExportStatus struct {
+	ID    int64
+	State string // PENDING, PROCESSING, COMPLETE or CANCELED
+	URL   string // address of exported file when export is complete
+}
+
+// Done - determines whether an export is finished
+func (status *ExportStatus) Done() bool {
+	return status.State == "COMPLETE" || status.State == "CANCELED"
+}
+
+// IExports - interface for the hubspot exports api
+type IExports interface {
+	Start(request *ExportRequest) (int64, error)
+	StartFromModel(name string, objecttype string, model *Model, filters ...*Filter) (int64, error)
+	Status(id int64) (*ExportStatus, error)
+	Wait(id int64, interval time.Duration, timeout time.Duration) (*ExportStatus, error)
+	Open(url string, model *Model) (*ExportReader, error)
+}
+
+// Exports - access to the crm exports api of hubspot using rest
+type Exports struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewExports - creates a new exports api
+func NewExports(rest IRestClient) *Exports {
+	return &Exports{rest: rest}
+}
+
+// Start - starts an export of crm objects
+// returns the id of the export task
+func (api *Exports) Start(request *ExportRequest) (int64, error) {
+	language := request.Language
+	if len(language) == 0 {
+		language = "EN"
+	}
+
+	body := map[string]interface{}{
+		"exportType":       "VIEW",
+		"format":           "CSV",
+		"exportName":       request.Name,
+		"objectType":       request.ObjectType,
+		"objectProperties": request.Properties,
+		"language":         language,
+		// export internal names and values so exported files can be mapped to models
+		"exportInternalValuesOptions": []string{"NAMES", "VALUES"}}
+
+	if len(request.Filters) > 0 {
+		body["publicCrmSearchRequest"] = map[string]interface{}{
+			"filters": request.Filters}
+	}
+
+	response, err := api.rest.Post("crm/v3/exports/export/async", body)
+	if err != nil {
+		return 0, err
+	}
+
+	return cast.ToInt64(response["id"]), nil
+}
+
+// StartFromModel - starts an export of all properties of a model
+func (api *Exports) StartFromModel(name string, objecttype string, model *Model, filters ...*Filter) (int64, error) {
+	var properties []string
+	for _, prop := range model.fieldorder {
+		if prop != model.id && prop != model.deleted && prop != model.contacts && prop != model.companies {
+			properties = append(properties, prop.HubspotName)
+		}
+	}
+
+	return api.Start(&ExportRequest{
+		Name:       name,
+		ObjectType: objecttype,
+		Properties: properties,
+		Filters:    filters})
+}
+
+// Status - get the status of an export
+func (api *Exports) Status(id int64) (*ExportStatus, error) {
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/exports/export/async/tasks/%d/status", id))
+	if err != nil {
+		return nil, notFound(err, "export", id)
+	}
+
+	return &ExportStatus{
+		ID:    id,
+		State: cast.ToString(response["status"]),
+		URL:   cast.ToString(response["result"])}, nil
+}
+
+// Wait - polls the status of an export until it is finished
+//
+// **Parameters**
+//   id      : id of export task
+//   interval: time to wait between status requests
+//   timeout : maximum time to wait for the export to finish (no limit if <= 0)
+func (api *Exports) Wait(id int64, interval time.Duration, timeout time.Duration) (*ExportStatus, error) {
+	start := time.Now()
+	for {
+		status, err := api.Status(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Done() {
+			return status, nil
+		}
+
+		if timeout > 0 && time.Since(start)+interval > timeout {
+			return status, errors.Errorf("Export %d not finished after %s", id, timeout)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// Open - opens an exported file to read its rows as entities of a model
+// the file is streamed while reading. Zipped exports are buffered in a temporary file.
+func (api *Exports) Open(url string, model *Model) (*ExportReader, error) {
+	rest, err := getRestV3(api.rest)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := rest.Download(url)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(stream)
+	signature, _ := reader.Peek(4)
+	if !bytes.Equal(signature, []byte("PK\x03\x04")) {
+		return newExportReader(reader, stream, model)
+	}
+
+	defer stream.Close()
+	return openZippedExport(reader, model)
+}
+
+// openZippedExport - reads the first file of a zipped export
+// zip archives need random access so the archive is written to a temporary file first
+func openZippedExport(stream io.Reader, model *Model) (*ExportReader, error) {
+	file, err := ioutil.TempFile("", "hubspot-export-*.zip")
+	if err != nil {
+		return nil, err
+	}
+
+	closer := &tempFileCloser{file: file}
+	size, err := io.Copy(file, stream)
+	if err != nil {
+		closer.Close()
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		closer.Close()
+		return nil, err
+	}
+
+	if len(archive.File) == 0 {
+		closer.Close()
+		return nil, errors.Errorf("Exported archive contains no files")
+	}
+
+	content, err := archive.File[0].Open()
+	if err != nil {
+		closer.Close()
+		return nil, err
+	}
+	closer.content = content
+
+	return newExportReader(content, closer, model)
+}
+
+// tempFileCloser - closes and removes a temporary file containing a zipped export
+type tempFileCloser struct {
+	file    *os.File
+	content io.ReadCloser
+}
+
+// Close - closes the archive and removes the temporary file
+func (closer *tempFileCloser) Close() error {
+	if closer.content != nil {
+		closer.content.Close()
+	}
+
+	closer.file.Close()
+	return os.Remove(closer.file.Name())
+}
+
+// ExportReader - reads rows of an exported file as entities of a model
+type ExportReader struct {
+	model   *Model
+	csv     *csv.Reader
+	closer  io.Closer
+	columns []*ModelProperty // properties mapped to columns, nil for unmapped columns
+	header  []string         // names of columns
+	idindex int              // index of column containing object id
+}
+
+func newExportReader(reader io.Reader, closer io.Closer, model *Model) (*ExportReader, error) {
+	csvreader := csv.NewReader(reader)
+	csvreader.FieldsPerRecord = -1
+
+	header, err := csvreader.Read()
+	if err != nil {
+		closer.Close()
+		if err == io.EOF {
+			return nil, errors.Errorf("Exported file contains no header")
+		}
+		return nil, err
+	}
+
+	exportreader := &ExportReader{
+		model:   model,
+		csv:     csvreader,
+		closer:  closer,
+		columns: make([]*ModelProperty, len(header)),
+		header:  make([]string, len(header)),
+		idindex: -1}
+
+	for index, name := range header {
+		// files might start with a byte order mark
+		if index == 0 {
+			name = string(bytes.TrimPrefix([]byte(name), []byte("\xef\xbb\xbf")))
+		}
+
+		exportreader.header[index] = name
+		if exportIDColumns[name] {
+			exportreader.idindex = index
+			continue
+		}
+
+		exportreader.columns[index] = model.getPropertyByHubspotName(name)
+	}
+
+	return exportreader, nil
+}
+
+// Next - reads the next row as entity
+// returns io.EOF if all rows were read
+func (reader *ExportReader) Next() (interface{}, error) {
+	row, err := reader.csv.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	entity := reflect.New(reader.model.datatype).Elem()
+
+	var errs ConversionErrors
+	if reader.idindex >= 0 && reader.idindex < len(row) && reader.model.id != nil {
+		errs.add(reader.model.id.SetValue(map[string]interface{}{"value": row[reader.idindex]}, "value", entity))
+	}
+
+	unmapped := make(map[string]interface{})
+	for index, value := range row {
+		if index >= len(reader.columns) || index == reader.idindex || len(value) == 0 {
+			continue
+		}
+
+		if reader.columns[index] == nil {
+			unmapped[reader.header[index]] = value
+			continue
+		}
+
+		errs.add(reader.columns[index].SetValue(map[string]interface{}{"value": value}, "value", entity))
+	}
+	reader.model.setExtra(unmapped, "", entity)
+
+	return readEntity(reader.model.loaded(entity, errs))
+}
+
+// Close - closes the exported file
+func (reader *ExportReader) Close() error {
+	return reader.closer.Close()
+}
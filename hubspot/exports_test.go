@@ -0,0 +1,107 @@
+package hubspot
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const exportedContacts string = "\xef\xbb\xbfRecord ID,email,firstname,date_of_birth,employees,unknown\n" +
+	"12,peter@lack.de,Peter,641520000000,3,x\n" +
+	"13,monika@left.de,,,,\n"
+
+func TestExportsInterfaceImpl(t *testing.T) {
+	var exports IExports = &Exports{}
+
+	if exports != nil {
+		return
+	}
+}
+
+func TestExportStartFromModel(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "88"}}
+	api := NewExports(rest)
+
+	id, err := api.StartFromModel("contacts", ExportObjectContact, MustNewModel(reflect.TypeOf(ImportedContact{})), Equals("lifecyclestage", "customer"))
+	require.NoError(t, err)
+	require.Equal(t, int64(88), id)
+	require.Equal(t, "POST crm/v3/exports/export/async?hapikey=xyz", rest.LastRequest())
+
+	request := rest.LastBody().(map[string]interface{})
+	require.Equal(t, "CONTACT", request["objectType"])
+	require.Equal(t, "CSV", request["format"])
+	require.Equal(t, "EN", request["language"])
+	require.Equal(t, []string{"email", "firstname", "date_of_birth", "synced", "employees"}, request["objectProperties"])
+	filters := request["publicCrmSearchRequest"].(map[string]interface{})["filters"].([]*Filter)
+	require.Equal(t, "lifecyclestage", filters[0].PropertyName)
+}
+
+func TestExportWait(t *testing.T) {
+	rest := &TestRest{Responses: []map[string]interface{}{
+		map[string]interface{}{"status": "PROCESSING"},
+		map[string]interface{}{"status": "COMPLETE", "result": "https://exports.hubspot.net/88.csv"}}}
+	api := NewExports(rest)
+
+	status, err := api.Wait(88, time.Millisecond, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "GET crm/v3/exports/export/async/tasks/88/status?hapikey=xyz", rest.LastRequest())
+	require.True(t, status.Done())
+	require.Equal(t, "https://exports.hubspot.net/88.csv", status.URL)
+}
+
+func readExport(t *testing.T, reader *ExportReader) []*ImportedContact {
+	var contacts []*ImportedContact
+	for {
+		entity, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		contacts = append(contacts, entity.(*ImportedContact))
+	}
+
+	require.NoError(t, reader.Close())
+	return contacts
+}
+
+func TestExportOpen(t *testing.T) {
+	rest := &TestRest{Content: exportedContacts}
+	api := NewExports(rest)
+
+	reader, err := api.Open("https://exports.hubspot.net/88.csv", MustNewModel(reflect.TypeOf(ImportedContact{})))
+	require.NoError(t, err)
+
+	contacts := readExport(t, reader)
+	require.Equal(t, 2, len(contacts))
+	require.Equal(t, int64(12), contacts[0].ID)
+	require.Equal(t, "peter@lack.de", contacts[0].EMail)
+	require.Equal(t, "Peter", contacts[0].Name)
+	require.Equal(t, 1990, contacts[0].Birthday.Year())
+	require.Equal(t, 3, contacts[0].Employees)
+	require.Equal(t, int64(13), contacts[1].ID)
+	require.True(t, contacts[1].Birthday.IsZero())
+}
+
+func TestExportOpenZipped(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	archive := zip.NewWriter(buffer)
+	file, err := archive.Create("contacts.csv")
+	require.NoError(t, err)
+	file.Write([]byte(exportedContacts))
+	require.NoError(t, archive.Close())
+
+	rest := &TestRest{Content: buffer.String()}
+	api := NewExports(rest)
+
+	reader, err := api.Open("https://exports.hubspot.net/88.zip", MustNewModel(reflect.TypeOf(ImportedContact{})))
+	require.NoError(t, err)
+
+	contacts := readExport(t, reader)
+	require.Equal(t, 2, len(contacts))
+	require.Equal(t, "monika@left.de", contacts[1].EMail)
+}
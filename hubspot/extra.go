@@ -0,0 +1,129 @@
+package hubspot
+
+import (
+	"reflect"
+)
+
+// isExtraType - determines whether a type can receive unmapped properties
+func isExtraType(datatype reflect.Type) bool {
+	return datatype.Kind() == reflect.Map && datatype.Key().Kind() == reflect.String
+}
+
+// systemProperties - properties owned by hubspot which are never sent from the extra field
+var systemProperties = map[string]bool{
+	"hs_object_id":          true,
+	"createdate":            true,
+	"hs_createdate":         true,
+	"lastmodifieddate":      true,
+	"hs_lastmodifieddate":   true,
+	"hs_merged_object_ids":  true,
+	"hs_created_by_user_id": true,
+	"hs_updated_by_user_id": true}
+
+// isMapped - determines whether a hubspot property is mapped to a field of the model
+func (mdl *Model) isMapped(name string) bool {
+	return mdl.getPropertyByHubspotName(name) != nil
+}
+
+// setExtra - transfers properties which are not mapped to a field to the extra field of an entity
+//
+// **Parameters**
+//   properties: properties of a hubspot response
+//   valuename : name of the value in property objects of v1 responses (empty if properties contain values directly)
+//   entity    : entity receiving the properties
+func (mdl *Model) setExtra(properties map[string]interface{}, valuename string, entity reflect.Value) {
+	if mdl.extra == nil {
+		return
+	}
+
+	field := mdl.extra.field(entity, true)
+	if !field.IsValid() {
+		return
+	}
+
+	elementtype := field.Type().Elem()
+	extra := reflect.MakeMap(field.Type())
+	for name, value := range properties {
+		if mdl.isMapped(name) {
+			continue
+		}
+
+		if len(valuename) > 0 {
+			property, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value = property[valuename]
+		}
+
+		if value == nil {
+			continue
+		}
+
+		if elementtype.Kind() == reflect.Interface {
+			extra.SetMapIndex(reflect.ValueOf(name), reflect.ValueOf(value))
+			continue
+		}
+
+		converted, err := convert(value, elementtype, mdl.options.getLocation())
+		if err == nil {
+			extra.SetMapIndex(reflect.ValueOf(name), toType(reflect.ValueOf(converted), elementtype))
+		}
+	}
+
+	field.Set(extra)
+}
+
+// getExtraValues - get the values of the extra field of an entity
+func (mdl *Model) getExtraValues(entity reflect.Value) map[string]interface{} {
+	if mdl.extra == nil {
+		return nil
+	}
+
+	field := mdl.extra.field(entity, false)
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+
+	values := make(map[string]interface{})
+	iterator := field.MapRange()
+	for iterator.Next() {
+		values[iterator.Key().String()] = iterator.Value().Interface()
+	}
+
+	return values
+}
+
+// getExtra - get the entries of the extra field of an entity to send to hubspot
+// updates of loaded entities only send entries which were added or changed since the entity was loaded,
+// all other requests send every entry. Entries of properties mapped to fields and of properties owned by
+// hubspot are never sent.
+func (mdl *Model) getExtra(entity reflect.Value, mode writeMode) map[string]interface{} {
+	var original map[string]interface{}
+	var snapshot *Snapshot
+	if mode == writeUpdate {
+		snapshot = mdl.getSnapshot(entity)
+	}
+	loaded := snapshot != nil && snapshot.values != nil
+	if loaded {
+		original, _ = snapshot.values[mdl.extra.StructField].(map[string]interface{})
+	}
+
+	extra := make(map[string]interface{})
+	for name, value := range mdl.getExtraValues(entity) {
+		if mdl.isMapped(name) || systemProperties[name] {
+			continue
+		}
+
+		if loaded {
+			previous, ok := original[name]
+			if ok && reflect.DeepEqual(previous, value) {
+				continue
+			}
+		}
+
+		extra[name] = mdl.extra.hubspotValue(value)
+	}
+
+	return extra
+}
@@ -0,0 +1,172 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type DynamicCompany struct {
+	Snapshot `hubspot:"snapshot"`
+	ID       int64 `hubspot:"id"`
+	Name     string
+	Extra    map[string]string `hubspot:"extra"`
+}
+
+type GenericCompany struct {
+	Snapshot   `hubspot:"snapshot"`
+	ID         int64                  `hubspot:"id"`
+	Name       string                 `hubspot:"name"`
+	Properties map[string]interface{} `hubspot:"extra"`
+}
+
+func TestExtraReadV1(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"companyId": 4886502,
+		"properties": map[string]interface{}{
+			"name":           map[string]interface{}{"value": "vertical GmbH"},
+			"website":        map[string]interface{}{"value": "www.vertical.de"},
+			"numberofemploy": map[string]interface{}{"value": "25"}}}}
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(DynamicCompany{})))
+
+	entity, err := api.Get(4886502)
+	require.NoError(t, err)
+
+	company := entity.(*DynamicCompany)
+	require.Equal(t, "vertical GmbH", company.Name)
+	require.Equal(t, map[string]string{"website": "www.vertical.de", "numberofemploy": "25"}, company.Extra)
+}
+
+func TestExtraReadV3(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(GenericCompany{}))
+	result, err := objectToEntity(map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":         "vertical GmbH",
+			"hs_object_id": "512",
+			"website":      nil}}, model)
+	require.NoError(t, err)
+	company := result.(*GenericCompany)
+
+	require.Equal(t, map[string]interface{}{"hs_object_id": "512"}, company.Properties)
+}
+
+func TestExtraWrite(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "512"}}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(GenericCompany{})))
+
+	_, err := api.Create(&GenericCompany{
+		Name: "vertical GmbH",
+		Properties: map[string]interface{}{
+			"name":    "ignored",
+			"website": "www.vertical.de",
+			"tags":    []string{"partner", "reseller"}}})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"name":    "vertical GmbH",
+		"website": "www.vertical.de",
+		"tags":    "partner;reseller"}, request)
+}
+
+func TestExtraWriteChangedOnly(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"companyId": 4886502,
+		"properties": map[string]interface{}{
+			"name":    map[string]interface{}{"value": "vertical GmbH"},
+			"website": map[string]interface{}{"value": "www.vertical.de"},
+			"phone":   map[string]interface{}{"value": "12345"}}}}
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(DynamicCompany{})))
+
+	entity, err := api.Get(4886502)
+	require.NoError(t, err)
+
+	company := entity.(*DynamicCompany)
+	company.Extra["phone"] = "54321"
+
+	_, err = api.Update(company.ID, company)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"phone": "54321"}, extractRequestProperties("name", rest.LastBody()))
+}
+
+func TestExtraWriteCopiedEntity(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":             "vertical GmbH",
+			"hs_object_id":     "512",
+			"createdate":       "2019-10-30T03:30:17.883Z",
+			"lastmodifieddate": "2019-12-07T16:50:06.678Z",
+			"website":          "www.vertical.de"}}}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(GenericCompany{})))
+
+	entity, err := api.Get(512)
+	require.NoError(t, err)
+
+	copied := *entity.(*GenericCompany)
+	copied.Properties["phone"] = "12345"
+
+	_, err = api.Update(copied.ID, &copied)
+	require.NoError(t, err)
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"phone": "12345"}, request)
+
+	_, err = api.Create(&copied)
+	require.NoError(t, err)
+	request = rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH", "website": "www.vertical.de", "phone": "12345"}, request)
+}
+
+func TestExtraSkipsSystemProperties(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "512"}}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(GenericCompany{})))
+
+	_, err := api.Create(&GenericCompany{
+		Name: "vertical GmbH",
+		Properties: map[string]interface{}{
+			"hs_object_id": "512",
+			"createdate":   "2019-10-30T03:30:17.883Z",
+			"website":      "www.vertical.de"}})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH", "website": "www.vertical.de"}, request)
+}
+
+func TestExtraWithoutSnapshot(t *testing.T) {
+	type UntrackedCompany struct {
+		ID    int64 `hubspot:"id"`
+		Name  string
+		Extra map[string]string `hubspot:"extra"`
+	}
+
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":    "vertical GmbH",
+			"website": "www.vertical.de"}}}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(UntrackedCompany{})))
+
+	entity, err := api.Get(512)
+	require.NoError(t, err)
+
+	// without a snapshot changes can't be tracked, so all entries are sent
+	company := entity.(*UntrackedCompany)
+	company.Extra["phone"] = "12345"
+	_, err = api.Update(company.ID, company)
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH", "website": "www.vertical.de", "phone": "12345"}, request)
+}
+
+func TestExtraInvalidType(t *testing.T) {
+	type InvalidExtra struct {
+		Extra []string `hubspot:"extra"`
+	}
+
+	_, err := NewModel(reflect.TypeOf(InvalidExtra{}))
+	require.Error(t, err)
+}
@@ -0,0 +1,318 @@
+package hubspot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// FileAccess - access level of a file
+type FileAccess string
+
+const (
+	FileAccessPublicIndexable    FileAccess = "PUBLIC_INDEXABLE"
+	FileAccessPublicNotIndexable FileAccess = "PUBLIC_NOT_INDEXABLE"
+	FileAccessPrivate            FileAccess = "PRIVATE"
+)
+
+// File - file stored in the hubspot file manager
+type File struct {
+	ID        int64
+	Name      string
+	Path      string
+	URL       string
+	Extension string
+	Type      string
+	Size      int64
+	Access    FileAccess
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Folder - folder of the hubspot file manager
+type Folder struct {
+	ID             int64
+	Name           string
+	Path           string
+	ParentFolderID int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FileUpload - file to upload to the file manager
+// either a folder id or a folder path has to be specified
+type FileUpload struct {
+	Name        string
+	ContentType string
+	Content     io.Reader
+	FolderID    int64
+	FolderPath  string
+	Access      FileAccess // access level of file (private if not specified)
+	Overwrite   bool       // whether to overwrite an existing file with the same name
+}
+
+// NoteTarget - objects a note is linked to
+type NoteTarget struct {
+	ContactIDs []int64
+	CompanyIDs []int64
+	DealIDs    []int64
+	TicketIDs  []int64
+}
+
+// IFiles - interface for the hubspot files api
+type IFiles interface {
+	Upload(upload *FileUpload) (*File, error)
+	Get(id int64) (*File, error)
+	Delete(id int64) error
+	SignedURL(id int64, expiration time.Duration) (string, error)
+	CreateFolder(name string, parentid int64) (*Folder, error)
+	DeleteFolder(id int64) error
+	ListFolders(parentid int64, page *Page) (*PageResponse, error)
+	AttachToNote(note string, target *NoteTarget, fileids ...int64) (int64, error)
+	UploadAndAttach(upload *FileUpload, note string, target *NoteTarget) (*File, int64, error)
+}
+
+// Files - access to the file manager of hubspot using rest
+type Files struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewFiles - creates a new files api
+func NewFiles(rest IRestClient) *Files {
+	return &Files{rest: rest}
+}
+
+func readFile(response map[string]interface{}) (*File, error) {
+	createdat, updatedat, err := readTimestamps(response, "createdAt", "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		ID:        cast.ToInt64(response["id"]),
+		Name:      cast.ToString(response["name"]),
+		Path:      cast.ToString(response["path"]),
+		URL:       cast.ToString(response["url"]),
+		Extension: cast.ToString(response["extension"]),
+		Type:      cast.ToString(response["type"]),
+		Size:      cast.ToInt64(response["size"]),
+		Access:    FileAccess(cast.ToString(response["access"])),
+		CreatedAt: createdat,
+		UpdatedAt: updatedat}, nil
+}
+
+func readFolder(response map[string]interface{}) (*Folder, error) {
+	createdat, updatedat, err := readTimestamps(response, "createdAt", "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Folder{
+		ID:             cast.ToInt64(response["id"]),
+		Name:           cast.ToString(response["name"]),
+		Path:           cast.ToString(response["path"]),
+		ParentFolderID: cast.ToInt64(response["parentFolderId"]),
+		CreatedAt:      createdat,
+		UpdatedAt:      updatedat}, nil
+}
+
+// Upload - uploads a file to the file manager
+// the file content is streamed to hubspot
+func (api *Files) Upload(upload *FileUpload) (*File, error) {
+	access := upload.Access
+	if len(access) == 0 {
+		access = FileAccessPrivate
+	}
+
+	options, err := json.Marshal(map[string]interface{}{
+		"access":    access,
+		"overwrite": upload.Overwrite})
+	if err != nil {
+		return nil, err
+	}
+
+	form := &MultipartForm{
+		Fields: map[string]string{
+			"fileName": upload.Name,
+			"options":  string(options)},
+		Files: []*MultipartFile{
+			&MultipartFile{
+				Field:       "file",
+				Name:        upload.Name,
+				ContentType: upload.ContentType,
+				Content:     upload.Content}}}
+
+	if upload.FolderID != 0 {
+		form.Fields["folderId"] = cast.ToString(upload.FolderID)
+	} else {
+		form.Fields["folderPath"] = upload.FolderPath
+	}
+
+	rest, err := getRestV3(api.rest)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := rest.PostMultipart("files/v3/files", form)
+	if err != nil {
+		return nil, err
+	}
+
+	return readFile(response)
+}
+
+// Get - get file information by id
+func (api *Files) Get(id int64) (*File, error) {
+	response, err := api.rest.Get(fmt.Sprintf("files/v3/files/%d", id))
+	if err != nil {
+		return nil, notFound(err, "file", id)
+	}
+
+	return readFile(response)
+}
+
+// Delete - deletes a file
+func (api *Files) Delete(id int64) error {
+	return api.rest.Delete(fmt.Sprintf("files/v3/files/%d", id))
+}
+
+// SignedURL - get a temporary url to download a private file
+//
+// **Parameters**
+//   id        : id of file
+//   expiration: duration the url is valid (hubspot default if <= 0)
+func (api *Files) SignedURL(id int64, expiration time.Duration) (string, error) {
+	var params []*Parameter
+	if expiration > 0 {
+		params = append(params, NewParameter("expirationSeconds", fmt.Sprintf("%d", int64(expiration/time.Second))))
+	}
+
+	response, err := api.rest.Get(fmt.Sprintf("files/v3/files/%d/signed-url", id), params...)
+	if err != nil {
+		return "", notFound(err, "file", id)
+	}
+
+	return cast.ToString(response["url"]), nil
+}
+
+// CreateFolder - creates a new folder
+//
+// **Parameters**
+//   name    : name of folder
+//   parentid: id of parent folder (0 to create a root folder)
+func (api *Files) CreateFolder(name string, parentid int64) (*Folder, error) {
+	request := map[string]interface{}{
+		"name": name}
+
+	if parentid != 0 {
+		request["parentFolderId"] = cast.ToString(parentid)
+	}
+
+	response, err := api.rest.Post("files/v3/folders", request)
+	if err != nil {
+		return nil, err
+	}
+
+	return readFolder(response)
+}
+
+// DeleteFolder - deletes a folder
+func (api *Files) DeleteFolder(id int64) error {
+	return api.rest.Delete(fmt.Sprintf("files/v3/folders/%d", id))
+}
+
+// ListFolders - lists a page of folders contained in a parent folder
+func (api *Files) ListFolders(parentid int64, page *Page) (*PageResponse, error) {
+	params := getPageParameters(page)
+	if parentid != 0 {
+		params = append(params, NewParameter("parentFolderId", cast.ToString(parentid)))
+	}
+
+	response, err := api.rest.Get("files/v3/folders/search", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := new(PageResponse)
+	readPaging(response, pr)
+
+	results, _ := response["results"].([]interface{})
+	for _, obj := range results {
+		folder, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		item, err := readFolder(folder)
+		if err != nil {
+			return nil, err
+		}
+
+		pr.Data = append(pr.Data, item)
+	}
+
+	return pr, nil
+}
+
+func nonNilIDs(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
+
+// AttachToNote - creates a note with file attachments on crm objects
+// returns the id of the created note engagement
+func (api *Files) AttachToNote(note string, target *NoteTarget, fileids ...int64) (int64, error) {
+	attachments := make([]map[string]interface{}, len(fileids))
+	for index, id := range fileids {
+		attachments[index] = map[string]interface{}{"id": id}
+	}
+
+	request := map[string]interface{}{
+		"engagement": map[string]interface{}{
+			"active":    true,
+			"type":      "NOTE",
+			"timestamp": time.Now().UnixNano() / int64(time.Millisecond)},
+		"associations": map[string]interface{}{
+			"contactIds": nonNilIDs(target.ContactIDs),
+			"companyIds": nonNilIDs(target.CompanyIDs),
+			"dealIds":    nonNilIDs(target.DealIDs),
+			"ticketIds":  nonNilIDs(target.TicketIDs),
+			"ownerIds":   []int64{}},
+		"attachments": attachments,
+		"metadata": map[string]interface{}{
+			"body": note}}
+
+	response, err := api.rest.Post("engagements/v1/engagements", request)
+	if err != nil {
+		return 0, err
+	}
+
+	engagement, ok := response["engagement"].(map[string]interface{})
+	if !ok {
+		return 0, errors.Errorf("Unexpected response structure from hubspot")
+	}
+
+	return cast.ToInt64(engagement["id"]), nil
+}
+
+// UploadAndAttach - uploads a file and attaches it to a new note on crm objects
+// returns the uploaded file and the id of the created note engagement
+func (api *Files) UploadAndAttach(upload *FileUpload, note string, target *NoteTarget) (*File, int64, error) {
+	file, err := api.Upload(upload)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	noteid, err := api.AttachToNote(note, target, file.ID)
+	if err != nil {
+		return file, 0, err
+	}
+
+	return file, noteid, nil
+}
@@ -0,0 +1,116 @@
+package hubspot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseFileUpload string = `{
+	"id": "27453914",
+	"name": "invoice-2020-001",
+	"path": "/invoices/invoice-2020-001.pdf",
+	"url": "https://f.hubspotusercontent.net/hubfs/62515/invoices/invoice-2020-001.pdf",
+	"extension": "pdf",
+	"type": "DOCUMENT",
+	"size": 48211,
+	"access": "PRIVATE",
+	"createdAt": "2020-04-01T10:00:00Z",
+	"updatedAt": "2020-04-01T10:00:00Z"
+  }`
+
+func TestFilesInterfaceImpl(t *testing.T) {
+	var files IFiles = &Files{}
+
+	if files != nil {
+		return
+	}
+}
+
+func TestFileUpload(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseFileUpload)}
+	api := NewFiles(rest)
+
+	content := strings.NewReader("%PDF-1.4")
+	file, err := api.Upload(&FileUpload{
+		Name:        "invoice-2020-001.pdf",
+		ContentType: "application/pdf",
+		Content:     content,
+		FolderPath:  "/invoices"})
+	require.NoError(t, err)
+	require.Equal(t, "POST files/v3/files?hapikey=xyz", rest.LastRequest())
+
+	form := rest.LastBody().(*MultipartForm)
+	require.Equal(t, "/invoices", form.Fields["folderPath"])
+	require.Equal(t, "invoice-2020-001.pdf", form.Fields["fileName"])
+	require.Equal(t, `{"access":"PRIVATE","overwrite":false}`, form.Fields["options"])
+	require.Equal(t, 1, len(form.Files))
+	require.Equal(t, "file", form.Files[0].Field)
+	require.Equal(t, "application/pdf", form.Files[0].ContentType)
+	require.Equal(t, content, form.Files[0].Content)
+
+	require.Equal(t, int64(27453914), file.ID)
+	require.Equal(t, FileAccessPrivate, file.Access)
+	require.Equal(t, int64(48211), file.Size)
+}
+
+func TestFileSignedURL(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"url": "https://signed.hubspot.net/file"}}
+	api := NewFiles(rest)
+
+	url, err := api.SignedURL(27453914, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "GET files/v3/files/27453914/signed-url?hapikey=xyz&expirationSeconds=3600", rest.LastRequest())
+	require.Equal(t, "https://signed.hubspot.net/file", url)
+}
+
+func TestFileUploadAndAttach(t *testing.T) {
+	rest := &TestRest{Responses: []map[string]interface{}{
+		readTestResponse(responseFileUpload),
+		map[string]interface{}{"engagement": map[string]interface{}{"id": float64(29090716)}}}}
+	api := NewFiles(rest)
+
+	file, noteid, err := api.UploadAndAttach(&FileUpload{
+		Name:     "contract.pdf",
+		Content:  strings.NewReader("%PDF-1.4"),
+		FolderID: 12}, "Signed contract", &NoteTarget{DealIDs: []int64{151088}})
+	require.NoError(t, err)
+	require.Equal(t, int64(27453914), file.ID)
+	require.Equal(t, int64(29090716), noteid)
+	require.Equal(t, "POST engagements/v1/engagements?hapikey=xyz", rest.LastRequest())
+
+	form := rest.bodies[0].(*MultipartForm)
+	require.Equal(t, "12", form.Fields["folderId"])
+	require.NotContains(t, form.Fields, "folderPath")
+
+	request := rest.LastBody().(map[string]interface{})
+	require.Equal(t, "NOTE", request["engagement"].(map[string]interface{})["type"])
+	associations := request["associations"].(map[string]interface{})
+	require.Equal(t, []int64{151088}, associations["dealIds"])
+	require.Equal(t, []int64{}, associations["contactIds"])
+	require.Equal(t, []map[string]interface{}{map[string]interface{}{"id": int64(27453914)}}, request["attachments"])
+	require.Equal(t, "Signed contract", request["metadata"].(map[string]interface{})["body"])
+}
+
+func TestFileCreateFolder(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "31", "name": "invoices", "parentFolderId": "12"}}
+	api := NewFiles(rest)
+
+	folder, err := api.CreateFolder("invoices", 12)
+	require.NoError(t, err)
+	require.Equal(t, "POST files/v3/folders?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, map[string]interface{}{"name": "invoices", "parentFolderId": "12"}, rest.LastBody())
+	require.Equal(t, int64(31), folder.ID)
+	require.Equal(t, int64(12), folder.ParentFolderID)
+}
+
+func TestFileInvalidTimestamp(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "31", "name": "invoices", "createdAt": "yesterday"}}
+	api := NewFiles(rest)
+
+	_, err := api.CreateFolder("invoices", 12)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "yesterday")
+}
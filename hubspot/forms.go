@@ -0,0 +1,318 @@
+package hubspot
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// address of the host receiving form submissions
+const formSubmissionAddress = "https://api.hsforms.com/submissions/v3/integration/secure/submit"
+
+// pattern used to extract the field name of a submission error message
+var formErrorFieldPattern = regexp.MustCompile(`'fields\.([^']+)'`)
+
+// FormFieldOption - option of a selectable form field
+type FormFieldOption struct {
+	Label string
+	Value string
+}
+
+// FormField - field of a hubspot form
+type FormField struct {
+	Name         string
+	Label        string
+	FieldType    string // eg. single_line_text, email, dropdown, multiple_checkboxes
+	ObjectTypeID string // type of object the field value is stored in (eg. 0-1 for contacts)
+	Required     bool
+	Hidden       bool
+	Options      []*FormFieldOption
+}
+
+// Form - definition of a hubspot form
+type Form struct {
+	ID        string
+	Name      string
+	FormType  string
+	Archived  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Fields    []*FormField
+}
+
+// FormContext - context information of a form submission used for attribution
+type FormContext struct {
+	HUTK      string `json:"hutk,omitempty"` // value of the hubspotutk cookie
+	PageURI   string `json:"pageUri,omitempty"`
+	PageName  string `json:"pageName,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// CommunicationConsent - consent to receive a type of communication
+type CommunicationConsent struct {
+	Value              bool   `json:"value"`
+	SubscriptionTypeID int64  `json:"subscriptionTypeId"`
+	Text               string `json:"text"`
+}
+
+// Consent - explicit consent given by a contact
+type Consent struct {
+	ConsentToProcess bool                    `json:"consentToProcess"`
+	Text             string                  `json:"text"`
+	Communications   []*CommunicationConsent `json:"communications,omitempty"`
+}
+
+// LegitimateInterest - legitimate interest as legal basis to process contact data
+type LegitimateInterest struct {
+	Value              bool   `json:"value"`
+	SubscriptionTypeID int64  `json:"subscriptionTypeId"`
+	LegalBasis         string `json:"legalBasis"` // LEGITIMATE_INTEREST_PQL or LEGITIMATE_INTEREST_CLIENT
+	Text               string `json:"text"`
+}
+
+// LegalConsent - legal consent options of a form submission
+// only one of consent and legitimate interest should be specified
+type LegalConsent struct {
+	Consent            *Consent            `json:"consent,omitempty"`
+	LegitimateInterest *LegitimateInterest `json:"legitimateInterest,omitempty"`
+}
+
+// FormSubmission - data submitted to a form
+type FormSubmission struct {
+	Data           interface{} // struct containing field values, mapped using the model of the api
+	SubmittedAt    time.Time   // time of submission (current time if not specified)
+	Context        *FormContext
+	LegalConsent   *LegalConsent
+	SkipValidation bool
+}
+
+// FormSubmissionResult - response of hubspot to a form submission
+type FormSubmissionResult struct {
+	InlineMessage string // message to show after submission
+	RedirectURI   string // address to redirect to after submission
+}
+
+// FormFieldError - error of a single submitted field
+type FormFieldError struct {
+	Field     string // name of the field (empty if error is not related to a field)
+	Message   string
+	ErrorType string // eg. INVALID_EMAIL, BLOCKED_EMAIL, REQUIRED_FIELD
+}
+
+// FormValidationError - error returned when hubspot rejects a form submission
+type FormValidationError struct {
+	Message string
+	Errors  []*FormFieldError
+}
+
+// Error - get error message
+func (err *FormValidationError) Error() string {
+	if len(err.Errors) == 0 {
+		return err.Message
+	}
+
+	return fmt.Sprintf("%s: %s", err.Message, err.Errors[0].Message)
+}
+
+// IForms - interface for the hubspot forms api
+type IForms interface {
+	List(page *Page) (*PageResponse, error)
+	Get(id string) (*Form, error)
+	Submit(id string, submission *FormSubmission) (*FormSubmissionResult, error)
+}
+
+// Forms - access to the forms api of hubspot using rest
+type Forms struct {
+	portalid int64       // id of hubspot portal containing the forms
+	model    *Model      // model used to convert submission data
+	rest     IRestClient // client used to send requests
+}
+
+// NewForms - creates a new forms api
+//
+// **Parameters**
+//   rest    : client used to send requests
+//   portalid: id of hubspot portal containing the forms
+//   model   : model used to convert submission data to form fields
+func NewForms(rest IRestClient, portalid int64, model *Model) *Forms {
+	return &Forms{
+		portalid: portalid,
+		model:    model,
+		rest:     rest}
+}
+
+func readFormField(data map[string]interface{}) *FormField {
+	field := &FormField{
+		Name:         cast.ToString(data["name"]),
+		Label:        cast.ToString(data["label"]),
+		FieldType:    cast.ToString(data["fieldType"]),
+		ObjectTypeID: cast.ToString(data["objectTypeId"]),
+		Required:     cast.ToBool(data["required"]),
+		Hidden:       cast.ToBool(data["hidden"])}
+
+	options, _ := data["options"].([]interface{})
+	for _, optionobj := range options {
+		option, ok := optionobj.(map[string]interface{})
+		if ok {
+			field.Options = append(field.Options, &FormFieldOption{
+				Label: cast.ToString(option["label"]),
+				Value: cast.ToString(option["value"])})
+		}
+	}
+
+	return field
+}
+
+func readForm(response map[string]interface{}) (*Form, error) {
+	createdat, updatedat, err := readTimestamps(response, "createdAt", "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	form := &Form{
+		ID:        cast.ToString(response["id"]),
+		Name:      cast.ToString(response["name"]),
+		FormType:  cast.ToString(response["formType"]),
+		Archived:  cast.ToBool(response["archived"]),
+		CreatedAt: createdat,
+		UpdatedAt: updatedat}
+
+	groups, _ := response["fieldGroups"].([]interface{})
+	for _, groupobj := range groups {
+		group, _ := groupobj.(map[string]interface{})
+		fields, _ := group["fields"].([]interface{})
+		for _, fieldobj := range fields {
+			field, ok := fieldobj.(map[string]interface{})
+			if ok {
+				form.Fields = append(form.Fields, readFormField(field))
+			}
+		}
+	}
+
+	return form, nil
+}
+
+// List - lists a page of form definitions
+func (api *Forms) List(page *Page) (*PageResponse, error) {
+	response, err := api.rest.Get("marketing/v3/forms/", getPageParameters(page)...)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := new(PageResponse)
+	readPaging(response, pr)
+
+	results, _ := response["results"].([]interface{})
+	for _, obj := range results {
+		form, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		item, err := readForm(form)
+		if err != nil {
+			return nil, err
+		}
+
+		pr.Data = append(pr.Data, item)
+	}
+
+	return pr, nil
+}
+
+// Get - get a form definition including its fields
+func (api *Forms) Get(id string) (*Form, error) {
+	response, err := api.rest.Get(fmt.Sprintf("marketing/v3/forms/%s", id))
+	if err != nil {
+		return nil, notFound(err, "form", id)
+	}
+
+	return readForm(response)
+}
+
+// readSubmissionError - converts an error response of a submission to a FormValidationError
+func readSubmissionError(err error) error {
+	resterr, ok := errors.Cause(err).(*RestError)
+	if !ok || resterr.StatusCode != 400 {
+		return err
+	}
+
+	var response struct {
+		Message string `json:"message"`
+		Errors  []struct {
+			Message   string `json:"message"`
+			ErrorType string `json:"errorType"`
+		} `json:"errors"`
+	}
+
+	if json.Unmarshal([]byte(resterr.Body), &response) != nil {
+		return err
+	}
+
+	validationerr := &FormValidationError{Message: response.Message}
+	for _, fielderr := range response.Errors {
+		item := &FormFieldError{
+			Message:   fielderr.Message,
+			ErrorType: fielderr.ErrorType}
+
+		match := formErrorFieldPattern.FindStringSubmatch(fielderr.Message)
+		if match != nil {
+			item.Field = match[1]
+		}
+
+		validationerr.Errors = append(validationerr.Errors, item)
+	}
+
+	return validationerr
+}
+
+// Submit - submits data to a form
+// returns a FormValidationError if hubspot rejects the submitted data. Submissions can update existing
+// contacts, so createonly properties are not sent.
+func (api *Forms) Submit(id string, submission *FormSubmission) (*FormSubmissionResult, error) {
+	var fields []map[string]interface{}
+	if submission.Data != nil {
+		properties, err := getProperties(submission.Data, "name", api.model, writeUpsert)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, property := range properties {
+			fields = append(fields, map[string]interface{}{
+				"name":  property["name"],
+				"value": toHubspotString(property["value"])})
+		}
+	}
+
+	request := map[string]interface{}{
+		"fields": fields}
+
+	if !submission.SubmittedAt.IsZero() {
+		request["submittedAt"] = toHubspotString(submission.SubmittedAt)
+	}
+
+	if submission.Context != nil {
+		request["context"] = submission.Context
+	}
+
+	if submission.LegalConsent != nil {
+		request["legalConsentOptions"] = submission.LegalConsent
+	}
+
+	if submission.SkipValidation {
+		request["skipValidation"] = true
+	}
+
+	response, err := api.rest.Post(fmt.Sprintf("%s/%d/%s", formSubmissionAddress, api.portalid, id), request)
+	if err != nil {
+		return nil, readSubmissionError(err)
+	}
+
+	return &FormSubmissionResult{
+		InlineMessage: cast.ToString(response["inlineMessage"]),
+		RedirectURI:   cast.ToString(response["redirectUri"])}, nil
+}
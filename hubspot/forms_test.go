@@ -0,0 +1,142 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseFormGet string = `{
+	"id": "5b5a1d58-0b3a-4b2d-b6d0-4b7f0d9f2d51",
+	"name": "Newsletter signup",
+	"formType": "hubspot",
+	"createdAt": "2020-02-01T10:00:00Z",
+	"updatedAt": "2020-03-01T10:00:00Z",
+	"archived": false,
+	"fieldGroups": [
+	  {
+		"groupType": "default_group",
+		"fields": [
+		  {
+			"objectTypeId": "0-1",
+			"name": "email",
+			"label": "Email",
+			"required": true,
+			"hidden": false,
+			"fieldType": "email"
+		  }
+		]
+	  },
+	  {
+		"groupType": "default_group",
+		"fields": [
+		  {
+			"objectTypeId": "0-1",
+			"name": "topic",
+			"label": "Topic",
+			"required": false,
+			"fieldType": "dropdown",
+			"options": [
+			  {"label": "Go", "value": "go"},
+			  {"label": "Rust", "value": "rust"}
+			]
+		  }
+		]
+	  }
+	]
+  }`
+
+const responseFormSubmitError string = `{
+	"status": "error",
+	"message": "The request is not valid",
+	"correlationId": "aeb7ae8c-bd1b-4e7c-a1b1-9f7d2cfa6d18",
+	"errors": [
+	  {
+		"message": "Error in 'fields.email'. Invalid email address",
+		"errorType": "INVALID_EMAIL"
+	  }
+	]
+  }`
+
+type Signup struct {
+	EMail string `hubspot:"name=email"`
+	Topic string
+	Age   int
+}
+
+func TestFormsInterfaceImpl(t *testing.T) {
+	var forms IForms = &Forms{}
+
+	if forms != nil {
+		return
+	}
+}
+
+func TestFormGet(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseFormGet)}
+	api := NewForms(rest, 62515, MustNewModel(reflect.TypeOf(Signup{})))
+
+	form, err := api.Get("5b5a1d58-0b3a-4b2d-b6d0-4b7f0d9f2d51")
+	require.NoError(t, err)
+	require.Equal(t, "GET marketing/v3/forms/5b5a1d58-0b3a-4b2d-b6d0-4b7f0d9f2d51?hapikey=xyz", rest.LastRequest())
+
+	require.Equal(t, "Newsletter signup", form.Name)
+	require.Equal(t, 2020, form.CreatedAt.Year())
+	require.Equal(t, 2, len(form.Fields))
+	require.Equal(t, "email", form.Fields[0].Name)
+	require.True(t, form.Fields[0].Required)
+	require.Equal(t, "0-1", form.Fields[0].ObjectTypeID)
+	require.Equal(t, 2, len(form.Fields[1].Options))
+	require.Equal(t, "rust", form.Fields[1].Options[1].Value)
+}
+
+func TestFormSubmit(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"inlineMessage": "Thanks for submitting the form."}}
+	api := NewForms(rest, 62515, MustNewModel(reflect.TypeOf(Signup{})))
+
+	result, err := api.Submit("5b5a1d58", &FormSubmission{
+		Data:        &Signup{EMail: "peter@lack.de", Age: 28},
+		SubmittedAt: time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC),
+		Context: &FormContext{
+			HUTK:      "60c2ccdfe4892f0fa0593940b12c11aa",
+			PageURI:   "https://www.vertical.de/newsletter",
+			IPAddress: "192.168.1.12"},
+		LegalConsent: &LegalConsent{
+			Consent: &Consent{
+				ConsentToProcess: true,
+				Text:             "I agree",
+				Communications: []*CommunicationConsent{
+					&CommunicationConsent{Value: true, SubscriptionTypeID: 999, Text: "Newsletter"}}}}})
+	require.NoError(t, err)
+	require.Equal(t, "POST https://api.hsforms.com/submissions/v3/integration/secure/submit/62515/5b5a1d58?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, "Thanks for submitting the form.", result.InlineMessage)
+
+	request := rest.LastBody().(map[string]interface{})
+	require.Equal(t, "1585735200000", request["submittedAt"])
+	require.Equal(t, "60c2ccdfe4892f0fa0593940b12c11aa", request["context"].(*FormContext).HUTK)
+	require.True(t, request["legalConsentOptions"].(*LegalConsent).Consent.ConsentToProcess)
+	require.NotContains(t, request, "skipValidation")
+
+	fields := extractRequestProperties("name", request["fields"])
+	require.Equal(t, "peter@lack.de", fields["email"])
+	require.Equal(t, "28", fields["age"])
+	require.NotContains(t, fields, "topic")
+}
+
+func TestFormSubmitValidationError(t *testing.T) {
+	rest := &TestRest{Error: &RestError{StatusCode: 400, Status: "400 Bad Request", Body: responseFormSubmitError}}
+	api := NewForms(rest, 62515, MustNewModel(reflect.TypeOf(Signup{})))
+
+	_, err := api.Submit("5b5a1d58", &FormSubmission{Data: &Signup{EMail: "peter"}})
+	require.Error(t, err)
+
+	validationerr, ok := err.(*FormValidationError)
+	require.True(t, ok)
+	require.Equal(t, "The request is not valid", validationerr.Message)
+	require.Equal(t, 1, len(validationerr.Errors))
+	require.Equal(t, "email", validationerr.Errors[0].Field)
+	require.Equal(t, "INVALID_EMAIL", validationerr.Errors[0].ErrorType)
+	require.Equal(t, "The request is not valid: Error in 'fields.email'. Invalid email address", err.Error())
+}
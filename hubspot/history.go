@@ -0,0 +1,158 @@
+package hubspot
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+var historytype reflect.Type = reflect.TypeOf(PropertyHistory{})
+
+// PropertyVersion - a value a property had at some point in time
+type PropertyVersion struct {
+	Value           string
+	Timestamp       time.Time
+	Source          string // source of the change (eg. API, CRM_UI, IMPORT)
+	SourceID        string // id of the source (eg. user or integration which changed the value)
+	UpdatedByUserID int64  // id of the user who changed the value (0 if not changed by a user)
+}
+
+// PropertyHistory - versions of properties by hubspot property name
+// add a field of this type tagged with 'history' to an entity to receive the history of its properties
+type PropertyHistory map[string][]*PropertyVersion
+
+// Latest - get the current version of a property
+// returns nil if no history of the property is known
+func (history PropertyHistory) Latest(name string) *PropertyVersion {
+	versions := history[name]
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[0]
+}
+
+func readPropertyVersion(data map[string]interface{}) (*PropertyVersion, error) {
+	timestamp, err := toTime(data["timestamp"])
+	if err != nil {
+		return nil, err
+	}
+
+	version := &PropertyVersion{
+		Value:           cast.ToString(data["value"]),
+		Timestamp:       timestamp,
+		Source:          cast.ToString(data["source"]),
+		SourceID:        cast.ToString(data["sourceId"]),
+		UpdatedByUserID: cast.ToInt64(data["updatedByUserId"])}
+
+	// v3 responses name the source 'sourceType'
+	if len(version.Source) == 0 {
+		version.Source = cast.ToString(data["sourceType"])
+	}
+
+	return version, nil
+}
+
+// readPropertyVersions - reads the history of a property in a v1 response
+// versions are returned ordered from newest to oldest like they are sent by hubspot
+func readPropertyVersions(property map[string]interface{}) ([]*PropertyVersion, error) {
+	versions, ok := property["versions"].([]interface{})
+	if !ok {
+		version, err := readPropertyVersion(property)
+		if err != nil {
+			return nil, err
+		}
+		return []*PropertyVersion{version}, nil
+	}
+
+	return readVersionList(versions)
+}
+
+func readVersionList(versions []interface{}) ([]*PropertyVersion, error) {
+	var history []*PropertyVersion
+	for _, versionobj := range versions {
+		versiondata, ok := versionobj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		version, err := readPropertyVersion(versiondata)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, version)
+	}
+
+	return history, nil
+}
+
+// setHistory - transfers the history of properties in a v1 response to the history field of an entity
+// properties with versions which can't be converted are added to errs
+func (mdl *Model) setHistory(properties map[string]interface{}, entity reflect.Value, errs *ConversionErrors) {
+	if mdl.history == nil {
+		return
+	}
+
+	history := make(PropertyHistory)
+	for name, value := range properties {
+		property, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		versions, err := readPropertyVersions(property)
+		if err != nil {
+			errs.add(mdl.historyError(name, property["versions"], err))
+			continue
+		}
+		history[name] = versions
+	}
+
+	mdl.setHistoryField(history, entity)
+}
+
+// setObjectHistory - transfers the history of properties in a v3 response to the history field of an entity
+// v3 responses only contain history for properties requested using 'propertiesWithHistory'
+func (mdl *Model) setObjectHistory(response map[string]interface{}, entity reflect.Value, errs *ConversionErrors) {
+	if mdl.history == nil {
+		return
+	}
+
+	properties, ok := response["propertiesWithHistory"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	history := make(PropertyHistory)
+	for name, value := range properties {
+		list, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+
+		versions, err := readVersionList(list)
+		if err != nil {
+			errs.add(mdl.historyError(name, list, err))
+			continue
+		}
+		history[name] = versions
+	}
+
+	mdl.setHistoryField(history, entity)
+}
+
+// historyError - creates an error for the history of a property which can't be converted
+func (mdl *Model) historyError(name string, value interface{}, err error) *ConversionError {
+	return &ConversionError{
+		Field:    mdl.history.StructField,
+		Property: name,
+		Value:    value,
+		Err:      err}
+}
+
+func (mdl *Model) setHistoryField(history PropertyHistory, entity reflect.Value) {
+	field := mdl.history.field(entity, true)
+	if field.IsValid() {
+		field.Set(reflect.ValueOf(history))
+	}
+}
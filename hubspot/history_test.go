@@ -0,0 +1,111 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type AuditedDeal struct {
+	ID      int64           `hubspot:"id"`
+	Name    string          `hubspot:"name=dealname"`
+	Stage   string          `hubspot:"name=dealstage"`
+	History PropertyHistory `hubspot:"history"`
+}
+
+const responseDealWithHistory string = `{
+	"id": "151088",
+	"properties": {
+	  "dealname": "Tim's Newer Deal",
+	  "dealstage": "closedwon"
+	},
+	"propertiesWithHistory": {
+	  "dealstage": [
+		{
+		  "value": "closedwon",
+		  "timestamp": "2020-04-02T10:00:00.000Z",
+		  "sourceType": "CRM_UI",
+		  "sourceId": "userId:9586504",
+		  "updatedByUserId": 9586504
+		},
+		{
+		  "value": "appointmentscheduled",
+		  "timestamp": "2020-04-01T10:00:00.000Z",
+		  "sourceType": "API"
+		}
+	  ]
+	},
+	"archived": false
+  }`
+
+func TestHistoryV1(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseDealCreate)}
+	api := NewDeals(rest, MustNewModel(reflect.TypeOf(AuditedDeal{})))
+
+	entity, err := api.Get(151088)
+	require.NoError(t, err)
+
+	deal := entity.(*AuditedDeal)
+	require.Equal(t, "appointmentscheduled", deal.Stage)
+
+	stage := deal.History.Latest("dealstage")
+	require.NotNil(t, stage)
+	require.Equal(t, "appointmentscheduled", stage.Value)
+	require.Equal(t, "API", stage.Source)
+	require.Equal(t, int64(1410381338943), stage.Timestamp.UnixNano()/int64(time.Millisecond))
+	require.Nil(t, deal.History.Latest("unknown"))
+}
+
+func TestHistoryV3(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseDealWithHistory)}
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(AuditedDeal{})))
+
+	entity, err := api.GetWithHistory(151088, "dealstage")
+	require.NoError(t, err)
+	require.Equal(t, "GET crm/v3/objects/deals/151088?hapikey=xyz&properties=dealstage&propertiesWithHistory=dealstage", rest.LastRequest())
+
+	deal := entity.(*AuditedDeal)
+	require.Equal(t, "closedwon", deal.Stage)
+
+	versions := deal.History["dealstage"]
+	require.Equal(t, 2, len(versions))
+	require.Equal(t, "CRM_UI", versions[0].Source)
+	require.Equal(t, int64(9586504), versions[0].UpdatedByUserID)
+	require.Equal(t, 2, versions[0].Timestamp.Day())
+	require.Equal(t, "appointmentscheduled", versions[1].Value)
+}
+
+func TestHistoryNotRequested(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(AuditedDeal{})))
+
+	entity, err := api.Get(512)
+	require.NoError(t, err)
+	require.Nil(t, entity.(*AuditedDeal).History)
+}
+
+func TestHistoryInvalidTimestampStrict(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id":         "151088",
+		"properties": map[string]interface{}{"dealstage": "closedwon"},
+		"propertiesWithHistory": map[string]interface{}{
+			"dealstage": []interface{}{
+				map[string]interface{}{"value": "closedwon", "timestamp": "yesterday"}}}}}
+
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(AuditedDeal{})))
+	entity, err := api.GetWithHistory(151088, "dealstage")
+	require.NoError(t, err)
+	require.Nil(t, entity.(*AuditedDeal).History["dealstage"])
+
+	api = NewObjects(rest, "deals", MustNewModelWithOptions(reflect.TypeOf(AuditedDeal{}), Options{Strict: true}))
+	entity, err = api.GetWithHistory(151088, "dealstage")
+	require.Error(t, err)
+	require.Nil(t, entity)
+
+	errs := err.(ConversionErrors)
+	require.Len(t, errs, 1)
+	require.Equal(t, "History", errs[0].Field)
+	require.Equal(t, "dealstage", errs[0].Property)
+}
@@ -27,7 +27,7 @@ func extractRequestProperties(nameproperty string, request interface{}) map[stri
 	return properties
 }
 
-func transferPropertiesToEntity(response map[string]interface{}, model *Model, entity reflect.Value) {
+func transferPropertiesToEntity(response map[string]interface{}, model *Model, entity reflect.Value, errs *ConversionErrors) {
 	properties, ok := response["properties"].(map[string]interface{})
 	if !ok {
 		return
@@ -39,16 +39,21 @@ func transferPropertiesToEntity(response map[string]interface{}, model *Model, e
 			continue
 		}
 
-		prop.SetValue(property, "value", entity)
+		errs.add(prop.SetValue(property, "value", entity))
 	}
+
+	model.setExtra(properties, "value", entity)
+	model.setHistory(properties, entity, errs)
 }
-func propertiesToEntity(response map[string]interface{}, model *Model) interface{} {
+func propertiesToEntity(response map[string]interface{}, model *Model) (interface{}, error) {
 	entity := reflect.New(model.datatype)
 	entity = entity.Elem()
 
+	var errs ConversionErrors
+
 	properties, ok := response["properties"].(map[string]interface{})
 	if !ok {
-		return entity.Addr().Interface()
+		return model.loaded(entity, errs)
 	}
 
 	for _, prop := range model.properties {
@@ -56,13 +61,18 @@ func propertiesToEntity(response map[string]interface{}, model *Model) interface
 		if !ok {
 			continue
 		}
-		prop.SetValue(property, "value", entity)
+		errs.add(prop.SetValue(property, "value", entity))
 	}
 
-	return entity.Addr().Interface()
+	model.setExtra(properties, "value", entity)
+	model.setHistory(properties, entity, &errs)
+	return model.loaded(entity, errs)
 }
 
-func getProperties(data interface{}, nameproperty string, mdl *Model) []map[string]interface{} {
+// getProperties - get properties of an entity in the format used by requests
+// properties which are not writable in the specified mode are not included.
+// Returns a ConversionError if the value of a field can't be converted.
+func getProperties(data interface{}, nameproperty string, mdl *Model, mode writeMode) ([]map[string]interface{}, error) {
 	var properties []map[string]interface{}
 
 	refvalue := reflect.ValueOf(data)
@@ -70,29 +80,122 @@ func getProperties(data interface{}, nameproperty string, mdl *Model) []map[stri
 		refvalue = refvalue.Elem()
 	}
 
-	for name, prop := range mdl.properties {
-		if prop.NoExport {
+	// only updates are limited to changed properties, created entities need all properties
+	var snapshot *Snapshot
+	if mode == writeUpdate {
+		snapshot = mdl.getSnapshot(refvalue)
+	}
+
+	for _, prop := range mdl.properties {
+		if !prop.writable(mode) {
 			continue
 		}
 
 		// check whether this is actually data which implements this field
 		// (useful if an entity was used which was not the source for the model)
-		propvalue := refvalue.FieldByName(name)
-		if propvalue.IsZero() {
+		propvalue := prop.field(refvalue, false)
+		if !propvalue.IsValid() {
+			continue
+		}
+
+		var value interface{}
+		var ok bool
+		var err error
+		if snapshot != nil {
+			value, ok, err = snapshot.exportValue(mdl, prop, propvalue)
+		} else {
+			value, ok, err = mdl.exportValue(propvalue)
+		}
+		if err != nil {
+			return nil, &ConversionError{
+				Field:    prop.StructField,
+				Property: prop.HubspotName,
+				Value:    propvalue.Interface(),
+				Err:      err}
+		}
+		if !ok {
 			continue
 		}
 
 		item := make(map[string]interface{})
 		item[nameproperty] = prop.HubspotName
-		item["value"] = propvalue.Interface()
+		item["value"] = prop.hubspotValue(value)
 		properties = append(properties, item)
 	}
 
-	return properties
+	if mdl.extra != nil {
+		for name, value := range mdl.getExtra(refvalue, mode) {
+			properties = append(properties, map[string]interface{}{
+				nameproperty: name,
+				"value":      value})
+		}
+	}
+
+	return properties, nil
 }
 
-func createPropertiesRequest(data interface{}, nameproperty string, mdl *Model) map[string]interface{} {
+// objectToEntity - converts an object of a crm v3 response to an entity
+func objectToEntity(response map[string]interface{}, model *Model) (interface{}, error) {
+	entity := reflect.New(model.datatype)
+	entity = entity.Elem()
+
+	var errs ConversionErrors
+
+	if model.id != nil {
+		errs.add(model.id.SetValue(response, "id", entity))
+	}
+
+	if model.deleted != nil {
+		errs.add(model.deleted.SetValue(response, "archived", entity))
+	}
+
+	properties, ok := response["properties"].(map[string]interface{})
+	if !ok {
+		return model.loaded(entity, errs)
+	}
+
+	for _, prop := range model.properties {
+		errs.add(prop.SetValue(properties, prop.HubspotName, entity))
+	}
+
+	model.setExtra(properties, "", entity)
+	model.setObjectHistory(response, entity, &errs)
+	return model.loaded(entity, errs)
+}
+
+// getPropertyMap - get properties of an entity in the format used by crm v3 requests
+func getPropertyMap(data interface{}, mdl *Model, mode writeMode) (map[string]interface{}, error) {
+	list, err := getProperties(data, "name", mdl, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]interface{})
+	for _, property := range list {
+		properties[cast.ToString(property["name"])] = property["value"]
+	}
+
+	return properties, nil
+}
+
+func createObjectRequest(data interface{}, mdl *Model, mode writeMode) (map[string]interface{}, error) {
+	properties, err := getPropertyMap(data, mdl, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	request := make(map[string]interface{})
+	request["properties"] = properties
+	return request, nil
+}
+
+func createPropertiesRequest(data interface{}, nameproperty string, mdl *Model, mode writeMode) (map[string]interface{}, error) {
+	properties, err := getProperties(data, nameproperty, mdl, mode)
+	if err != nil {
+		return nil, err
+	}
+
 	request := make(map[string]interface{})
-	request["properties"] = getProperties(data, nameproperty, mdl)
-	return request
+	request["properties"] = properties
+	return request, nil
 }
@@ -0,0 +1,377 @@
+package hubspot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// ids of crm object types used by the imports and exports apis
+const (
+	ObjectTypeContact  = "0-1"
+	ObjectTypeCompany  = "0-2"
+	ObjectTypeDeal     = "0-3"
+	ObjectTypeTicket   = "0-5"
+	ObjectTypeProduct  = "0-7"
+	ObjectTypeLineItem = "0-8"
+)
+
+// column type of columns containing hubspot object ids
+const objectIDColumnType = "HUBSPOT_OBJECT_ID"
+
+// states of an import which will not change anymore
+var finalImportStates = map[string]bool{
+	"DONE":     true,
+	"FAILED":   true,
+	"CANCELED": true}
+
+// ImportColumn - mapping of a csv column to a hubspot property
+type ImportColumn struct {
+	Name         string // name of column in csv header
+	Property     string // hubspot property to import column to
+	ObjectTypeID string // type of object the property belongs to
+	IDColumnType string // HUBSPOT_OBJECT_ID or HUBSPOT_ALTERNATE_ID if column identifies existing objects
+}
+
+// ImportRequest - request to import a csv file
+type ImportRequest struct {
+	Name       string    // name of import shown in hubspot
+	FileName   string    // name of the imported file
+	Content    io.Reader // csv data including a header row
+	Columns    []*ImportColumn
+	DateFormat string // format of dates in csv (MONTH_DAY_YEAR, DAY_MONTH_YEAR or YEAR_MONTH_DAY)
+}
+
+// ImportStatus - status of an import
+type ImportStatus struct {
+	ID        int64
+	Name      string
+	State     string           // eg. STARTED, PROCESSING, DONE, FAILED, CANCELED, DEFERRED
+	Counters  map[string]int64 // eg. TOTAL_ROWS, CREATED_OBJECTS, UPDATED_OBJECTS
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Done - determines whether an import is finished
+func (status *ImportStatus) Done() bool {
+	return finalImportStates[status.State]
+}
+
+// ImportError - error which occured when importing a row
+type ImportError struct {
+	ID           string
+	ErrorType    string // eg. INVALID_EMAIL, INVALID_OPTION
+	InvalidValue string
+	ObjectType   string
+	LineNumber   int64    // line of csv containing the error
+	RowData      []string // values of the row containing the error
+}
+
+// IImports - interface for the hubspot imports api
+type IImports interface {
+	Start(request *ImportRequest) (*ImportStatus, error)
+	StartFromEntities(name string, objecttypeid string, model *Model, entities []interface{}) (*ImportStatus, error)
+	Get(id int64) (*ImportStatus, error)
+	Wait(id int64, interval time.Duration, timeout time.Duration) (*ImportStatus, error)
+	Cancel(id int64) error
+	Errors(id int64, page *Page) (*PageResponse, error)
+}
+
+// Imports - access to the crm imports api of hubspot using rest
+type Imports struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewImports - creates a new imports api
+func NewImports(rest IRestClient) *Imports {
+	return &Imports{rest: rest}
+}
+
+// ImportColumns - creates the column mapping for csv data generated from entities of a model
+// the id column is only mapped if the entities are meant to update existing objects. Create only
+// properties are not mapped in this case.
+func ImportColumns(objecttypeid string, model *Model, includeid bool) []*ImportColumn {
+	mode := writeCreate
+	var columns []*ImportColumn
+	if includeid && model.id != nil {
+		mode = writeUpdate
+		columns = append(columns, &ImportColumn{
+			Name:         "hs_object_id",
+			Property:     "hs_object_id",
+			ObjectTypeID: objecttypeid,
+			IDColumnType: objectIDColumnType})
+	}
+
+	for _, prop := range model.fieldorder {
+		if !prop.writable(mode) {
+			continue
+		}
+
+		columns = append(columns, &ImportColumn{
+			Name:         prop.HubspotName,
+			Property:     prop.HubspotName,
+			ObjectTypeID: objecttypeid})
+	}
+
+	return columns
+}
+
+// WriteImportCSV - writes entities of a model as csv data usable for imports
+// columns have to be created using ImportColumns
+func WriteImportCSV(writer io.Writer, model *Model, columns []*ImportColumn, entities []interface{}) error {
+	csvwriter := csv.NewWriter(writer)
+
+	header := make([]string, len(columns))
+	for index, column := range columns {
+		header[index] = column.Name
+	}
+
+	err := csvwriter.Write(header)
+	if err != nil {
+		return err
+	}
+
+	idcolumn := len(columns) > 0 && columns[0].IDColumnType == objectIDColumnType
+	mode := writeCreate
+	if idcolumn {
+		mode = writeUpdate
+	}
+
+	var exported []*ModelProperty
+	for _, prop := range model.fieldorder {
+		if prop.writable(mode) {
+			exported = append(exported, prop)
+		}
+	}
+	for _, entity := range entities {
+		refvalue := reflect.ValueOf(entity)
+		if refvalue.Kind() == reflect.Ptr {
+			refvalue = refvalue.Elem()
+		}
+
+		var row []string
+		if idcolumn {
+			row = append(row, cast.ToString(model.GetID(entity)))
+		}
+
+		for _, prop := range exported {
+			field := prop.field(refvalue, false)
+			if !field.IsValid() {
+				row = append(row, "")
+				continue
+			}
+
+			value, ok, err := model.exportValue(field)
+			if err != nil {
+				return &ConversionError{
+					Field:    prop.StructField,
+					Property: prop.HubspotName,
+					Value:    field.Interface(),
+					Err:      err}
+			}
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+
+			row = append(row, toHubspotString(prop.hubspotValue(value)))
+		}
+
+		err = csvwriter.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	csvwriter.Flush()
+	return csvwriter.Error()
+}
+
+func readImportStatus(response map[string]interface{}) (*ImportStatus, error) {
+	createdat, updatedat, err := readTimestamps(response, "createdAt", "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ImportStatus{
+		ID:        cast.ToInt64(response["id"]),
+		Name:      cast.ToString(response["importName"]),
+		State:     cast.ToString(response["state"]),
+		Counters:  make(map[string]int64),
+		CreatedAt: createdat,
+		UpdatedAt: updatedat}
+
+	metadata, ok := response["metadata"].(map[string]interface{})
+	if ok {
+		counters, _ := metadata["counters"].(map[string]interface{})
+		for key, value := range counters {
+			status.Counters[key] = cast.ToInt64(value)
+		}
+	}
+
+	return status, nil
+}
+
+// Start - starts an import of csv data
+func (api *Imports) Start(request *ImportRequest) (*ImportStatus, error) {
+	mappings := make([]map[string]interface{}, len(request.Columns))
+	for index, column := range request.Columns {
+		mapping := map[string]interface{}{
+			"columnName":         column.Name,
+			"propertyName":       column.Property,
+			"columnObjectTypeId": column.ObjectTypeID}
+
+		if len(column.IDColumnType) > 0 {
+			mapping["idColumnType"] = column.IDColumnType
+		}
+		mappings[index] = mapping
+	}
+
+	file := map[string]interface{}{
+		"fileName":   request.FileName,
+		"fileFormat": "CSV",
+		"fileImportPage": map[string]interface{}{
+			"hasHeader":      true,
+			"columnMappings": mappings}}
+
+	if len(request.DateFormat) > 0 {
+		file["dateFormat"] = request.DateFormat
+	}
+
+	importrequest, err := json.Marshal(map[string]interface{}{
+		"name":  request.Name,
+		"files": []interface{}{file}})
+	if err != nil {
+		return nil, err
+	}
+
+	rest, err := getRestV3(api.rest)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := rest.PostMultipart("crm/v3/imports", &MultipartForm{
+		Fields: map[string]string{
+			"importRequest": string(importrequest)},
+		Files: []*MultipartFile{
+			&MultipartFile{
+				Field:       "files",
+				Name:        request.FileName,
+				ContentType: "text/csv",
+				Content:     request.Content}}})
+	if err != nil {
+		return nil, err
+	}
+
+	return readImportStatus(response)
+}
+
+// StartFromEntities - starts an import of entities of a model
+// the csv data is generated while it is uploaded. If all entities contain an id existing objects
+// are updated, otherwise new objects are created.
+func (api *Imports) StartFromEntities(name string, objecttypeid string, model *Model, entities []interface{}) (*ImportStatus, error) {
+	includeid := model.id != nil && len(entities) > 0
+	for _, entity := range entities {
+		if !includeid {
+			break
+		}
+		includeid = cast.ToInt64(model.GetID(entity)) != 0
+	}
+
+	columns := ImportColumns(objecttypeid, model, includeid)
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(WriteImportCSV(writer, model, columns, entities))
+	}()
+	defer reader.Close()
+
+	return api.Start(&ImportRequest{
+		Name:     name,
+		FileName: fmt.Sprintf("%s.csv", name),
+		Content:  reader,
+		Columns:  columns})
+}
+
+// Get - get the status of an import
+func (api *Imports) Get(id int64) (*ImportStatus, error) {
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/imports/%d", id))
+	if err != nil {
+		return nil, notFound(err, "import", id)
+	}
+
+	return readImportStatus(response)
+}
+
+// Wait - polls the status of an import until it is finished
+//
+// **Parameters**
+//   id      : id of import
+//   interval: time to wait between status requests
+//   timeout : maximum time to wait for the import to finish (no limit if <= 0)
+func (api *Imports) Wait(id int64, interval time.Duration, timeout time.Duration) (*ImportStatus, error) {
+	start := time.Now()
+	for {
+		status, err := api.Get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Done() {
+			return status, nil
+		}
+
+		if timeout > 0 && time.Since(start)+interval > timeout {
+			return status, errors.Errorf("Import %d not finished after %s", id, timeout)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// Cancel - cancels an active import
+func (api *Imports) Cancel(id int64) error {
+	_, err := api.rest.Post(fmt.Sprintf("crm/v3/imports/%d/cancel", id), nil)
+	return err
+}
+
+// Errors - lists a page of errors which occured during an import
+func (api *Imports) Errors(id int64, page *Page) (*PageResponse, error) {
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/imports/%d/errors", id), getPageParameters(page)...)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := new(PageResponse)
+	readPaging(response, pr)
+
+	results, _ := response["results"].([]interface{})
+	for _, obj := range results {
+		errordata, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		importerr := &ImportError{
+			ID:           cast.ToString(errordata["id"]),
+			ErrorType:    cast.ToString(errordata["errorType"]),
+			InvalidValue: cast.ToString(errordata["invalidValue"]),
+			ObjectType:   cast.ToString(errordata["objectType"])}
+
+		source, ok := errordata["sourceData"].(map[string]interface{})
+		if ok {
+			importerr.LineNumber = cast.ToInt64(source["lineNumber"])
+			importerr.RowData = cast.ToStringSlice(source["rowData"])
+		}
+
+		pr.Data = append(pr.Data, importerr)
+	}
+
+	return pr, nil
+}
@@ -0,0 +1,148 @@
+package hubspot
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseImportStatus string = `{
+	"id": "4211303",
+	"importName": "migration",
+	"state": "DONE",
+	"createdAt": "2020-04-01T10:00:00Z",
+	"updatedAt": "2020-04-01T10:05:00Z",
+	"metadata": {
+	  "counters": {
+		"TOTAL_ROWS": 2,
+		"CREATED_OBJECTS": 1,
+		"UPDATED_OBJECTS": 1
+	  }
+	}
+  }`
+
+type ImportedContact struct {
+	ID        int64 `hubspot:"id"`
+	EMail     string
+	Name      string    `hubspot:"name=firstname"`
+	Birthday  time.Time `hubspot:"name=date_of_birth"`
+	Synced    bool      `hubspot:"noexport"`
+	Employees int       `hubspot:"name=employees"`
+}
+
+func TestImportsInterfaceImpl(t *testing.T) {
+	var imports IImports = &Imports{}
+
+	if imports != nil {
+		return
+	}
+}
+
+func TestImportWriteCSV(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(ImportedContact{}))
+	columns := ImportColumns(ObjectTypeContact, model, true)
+	require.Equal(t, 5, len(columns))
+	require.Equal(t, "hs_object_id", columns[0].Property)
+	require.Equal(t, "HUBSPOT_OBJECT_ID", columns[0].IDColumnType)
+	require.Equal(t, "email", columns[1].Property)
+	require.Equal(t, "0-1", columns[1].ObjectTypeID)
+
+	buffer := new(bytes.Buffer)
+	err := WriteImportCSV(buffer, model, columns, []interface{}{
+		&ImportedContact{ID: 12, EMail: "peter@lack.de", Name: "Peter, the great", Birthday: time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC), Employees: 3},
+		&ImportedContact{ID: 13, EMail: "monika@left.de"}})
+	require.NoError(t, err)
+	require.Equal(t, "hs_object_id,email,firstname,date_of_birth,employees\n"+
+		"12,peter@lack.de,\"Peter, the great\",641520000000,3\n"+
+		"13,monika@left.de,,,\n", buffer.String())
+}
+
+func TestImportColumnsCreateOnly(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(StagedContact{}))
+
+	columns := ImportColumns(ObjectTypeContact, model, false)
+	require.Equal(t, 2, len(columns))
+	require.Equal(t, "email", columns[0].Property)
+	require.Equal(t, "lifecyclestage", columns[1].Property)
+
+	columns = ImportColumns(ObjectTypeContact, model, true)
+	require.Equal(t, 2, len(columns))
+	require.Equal(t, "hs_object_id", columns[0].Property)
+	require.Equal(t, "email", columns[1].Property)
+
+	buffer := new(bytes.Buffer)
+	err := WriteImportCSV(buffer, model, columns, []interface{}{&StagedContact{ID: 12, Email: "peter@lack.de", Stage: "lead"}})
+	require.NoError(t, err)
+	require.Equal(t, "hs_object_id,email\n12,peter@lack.de\n", buffer.String())
+}
+
+func TestImportStartFromEntities(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "4211303", "state": "STARTED"}}
+	api := NewImports(rest)
+
+	status, err := api.StartFromEntities("migration", ObjectTypeContact, MustNewModel(reflect.TypeOf(ImportedContact{})), []interface{}{
+		&ImportedContact{EMail: "peter@lack.de"}})
+	require.NoError(t, err)
+	require.Equal(t, "POST crm/v3/imports?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, int64(4211303), status.ID)
+	require.False(t, status.Done())
+
+	form := rest.LastBody().(*MultipartForm)
+	require.Equal(t, "files", form.Files[0].Field)
+	require.Equal(t, "migration.csv", form.Files[0].Name)
+
+	var request map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(form.Fields["importRequest"]), &request))
+	require.Equal(t, "migration", request["name"])
+	file := request["files"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "CSV", file["fileFormat"])
+	mappings := file["fileImportPage"].(map[string]interface{})["columnMappings"].([]interface{})
+
+	// new contacts are created, so no id column is sent
+	require.Equal(t, 4, len(mappings))
+	require.Equal(t, "email", mappings[0].(map[string]interface{})["propertyName"])
+	require.NotContains(t, mappings[0], "idColumnType")
+}
+
+func TestImportWait(t *testing.T) {
+	rest := &TestRest{Responses: []map[string]interface{}{
+		map[string]interface{}{"id": "4211303", "state": "PROCESSING"},
+		readTestResponse(responseImportStatus)}}
+	api := NewImports(rest)
+
+	status, err := api.Wait(4211303, time.Millisecond, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(rest.requests))
+	require.Equal(t, "GET crm/v3/imports/4211303?hapikey=xyz", rest.LastRequest())
+	require.True(t, status.Done())
+	require.Equal(t, int64(2), status.Counters["TOTAL_ROWS"])
+	require.Equal(t, int64(1), status.Counters["CREATED_OBJECTS"])
+}
+
+func TestImportErrors(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{
+				"id":           "e1",
+				"errorType":    "INVALID_EMAIL",
+				"invalidValue": "peter",
+				"objectType":   "CONTACT",
+				"sourceData": map[string]interface{}{
+					"lineNumber": float64(3),
+					"rowData":    []interface{}{"", "peter", "Peter"}}}}}}
+	api := NewImports(rest)
+
+	response, err := api.Errors(4211303, NewPage(0, 50))
+	require.NoError(t, err)
+	require.Equal(t, "GET crm/v3/imports/4211303/errors?hapikey=xyz&limit=50", rest.LastRequest())
+	require.False(t, response.HasMore)
+
+	importerr := response.Data[0].(*ImportError)
+	require.Equal(t, "INVALID_EMAIL", importerr.ErrorType)
+	require.Equal(t, int64(3), importerr.LineNumber)
+	require.Equal(t, []string{"", "peter", "Peter"}, importerr.RowData)
+}
@@ -0,0 +1,100 @@
+package hubspot
+
+import "reflect"
+
+// LineItem - line item of a deal or quote
+// can be used as entity type of a model for the line items api
+type LineItem struct {
+	ID                 int64   `hubspot:"id"`
+	ProductID          int64   `hubspot:"name=hs_product_id"`
+	Name               string  `hubspot:"name=name"`
+	Quantity           float64 `hubspot:"name=quantity"`
+	Price              float64 `hubspot:"name=price"`
+	Discount           float64 `hubspot:"name=discount"`               // absolute discount per unit
+	DiscountPercentage float64 `hubspot:"name=hs_discount_percentage"` // discount per unit in percent
+	Amount             float64 `hubspot:"name=amount,noexport"`        // total amount calculated by hubspot
+}
+
+// model used to compute deal amounts independent of the model of the api
+var lineItemModel = MustNewModel(reflect.TypeOf(LineItem{}))
+
+// ILineItems - interface for the hubspot line items api
+type ILineItems interface {
+	IObjects
+	CreateForDeal(dealid int64, item interface{}) (interface{}, error)
+	ListForDeal(dealid int64, props ...string) ([]interface{}, error)
+	DealAmount(dealid int64) (float64, error)
+}
+
+// LineItems - access to line items of hubspot using rest
+type LineItems struct {
+	*Objects
+	associations *Associations // api used to link line items to deals
+}
+
+// NewLineItems - creates a new line items api
+func NewLineItems(rest IRestClient, model *Model) *LineItems {
+	return &LineItems{
+		Objects:      NewObjects(rest, "line_items", model),
+		associations: NewAssociations(rest)}
+}
+
+// CreateForDeal - creates a line item and associates it to a deal
+func (api *LineItems) CreateForDeal(dealid int64, item interface{}) (interface{}, error) {
+	// entities which can't be converted are associated anyway since the line item already exists
+	entity, id, converr := api.create(item)
+	if _, ok := converr.(ConversionErrors); converr != nil && !ok {
+		return nil, converr
+	}
+
+	err := api.associations.Create(id, dealid, AssociationLineItemToDeal)
+	if err != nil {
+		// the line item already exists, so it is returned to allow the caller to retry or clean up
+		return entity, err
+	}
+
+	return entity, converr
+}
+
+// ListForDeal - lists all line items associated to a deal
+func (api *LineItems) ListForDeal(dealid int64, props ...string) ([]interface{}, error) {
+	ids, err := api.associations.ListAll(dealid, AssociationDealToLineItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.BatchRead(ids, props...)
+}
+
+// LineItemAmount - computes the total amount of a line item
+// a discount percentage takes precedence over an absolute discount
+func LineItemAmount(item *LineItem) float64 {
+	unitprice := item.Price
+	if item.DiscountPercentage != 0 {
+		unitprice -= item.Price * item.DiscountPercentage / 100
+	} else {
+		unitprice -= item.Discount
+	}
+
+	return unitprice * item.Quantity
+}
+
+// DealAmount - computes the amount of a deal by summing up the amounts of its line items
+func (api *LineItems) DealAmount(dealid int64) (float64, error) {
+	ids, err := api.associations.ListAll(dealid, AssociationDealToLineItem)
+	if err != nil {
+		return 0, err
+	}
+
+	items, err := NewObjects(api.rest, "line_items", lineItemModel).BatchRead(ids)
+	if err != nil {
+		return 0, err
+	}
+
+	var amount float64
+	for _, item := range items {
+		amount += LineItemAmount(item.(*LineItem))
+	}
+
+	return amount, nil
+}
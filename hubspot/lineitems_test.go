@@ -0,0 +1,149 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const responseLineItemBatch string = `{
+	"status": "COMPLETE",
+	"results": [
+	  {
+		"id": "1001",
+		"properties": {
+		  "name": "Consulting",
+		  "quantity": "2",
+		  "price": "100.00",
+		  "discount": "10"
+		}
+	  },
+	  {
+		"id": "1002",
+		"properties": {
+		  "name": "License",
+		  "quantity": "1",
+		  "price": "500.00",
+		  "hs_discount_percentage": "20",
+		  "discount": "10"
+		}
+	  }
+	]
+  }`
+
+func TestLineItemsInterfaceImpl(t *testing.T) {
+	var lineitems ILineItems = &LineItems{}
+	var products IProducts = &Products{}
+	var quotes IQuotes = &Quotes{}
+
+	if lineitems != nil || products != nil || quotes != nil {
+		return
+	}
+}
+
+func TestLineItemCreateForDeal(t *testing.T) {
+	rest := &TestRest{Responses: []map[string]interface{}{
+		map[string]interface{}{
+			"id":         "1001",
+			"properties": map[string]interface{}{"name": "Consulting", "hs_product_id": "77"}}}}
+	api := NewLineItems(rest, lineItemModel)
+
+	created, err := api.CreateForDeal(151088, &LineItem{
+		ProductID: 77,
+		Name:      "Consulting",
+		Quantity:  2,
+		Price:     100,
+		Amount:    200})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(rest.requests))
+	require.Equal(t, "POST crm/v3/objects/line_items?hapikey=xyz", rest.requests[0])
+	require.Equal(t, "PUT crm-associations/v1/associations?hapikey=xyz", rest.LastRequest())
+
+	properties := rest.bodies[0].(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, int64(77), properties["hs_product_id"])
+	require.Equal(t, float64(2), properties["quantity"])
+	require.NotContains(t, properties, "amount")
+
+	association := rest.LastBody().(map[string]interface{})
+	require.Equal(t, int64(1001), association["fromObjectId"])
+	require.Equal(t, int64(151088), association["toObjectId"])
+	require.Equal(t, 20, association["definitionId"])
+
+	item := created.(*LineItem)
+	require.Equal(t, int64(1001), item.ID)
+	require.Equal(t, int64(77), item.ProductID)
+}
+
+func TestLineItemDealAmount(t *testing.T) {
+	rest := &TestRest{Responses: []map[string]interface{}{
+		map[string]interface{}{
+			"results": []interface{}{float64(1001), float64(1002)},
+			"hasMore": false},
+		readTestResponse(responseLineItemBatch)}}
+	api := NewLineItems(rest, MustNewModel(reflect.TypeOf(Product{})))
+
+	amount, err := api.DealAmount(151088)
+	require.NoError(t, err)
+	require.Equal(t, "GET crm-associations/v1/associations/151088/HUBSPOT_DEFINED/19?hapikey=xyz", rest.requests[0])
+	require.Equal(t, "POST crm/v3/objects/line_items/batch/read?hapikey=xyz", rest.LastRequest())
+
+	inputs := rest.LastBody().(map[string]interface{})["inputs"].([]map[string]interface{})
+	require.Equal(t, "1001", inputs[0]["id"])
+	require.Equal(t, "1002", inputs[1]["id"])
+
+	// 2 * (100 - 10) + 1 * (500 - 20%)
+	require.Equal(t, float64(580), amount)
+}
+
+func TestQuoteAddLineItems(t *testing.T) {
+	rest := &TestRest{}
+	api := NewQuotes(rest, MustNewModel(reflect.TypeOf(Quote{})))
+
+	err := api.AddLineItems(300, 1001, 1002)
+	require.NoError(t, err)
+	require.Equal(t, "PUT crm-associations/v1/associations/create-batch?hapikey=xyz", rest.LastRequest())
+
+	request := rest.LastBody().([]map[string]interface{})
+	require.Equal(t, 2, len(request))
+	require.Equal(t, int64(300), request[0]["fromObjectId"])
+	require.Equal(t, int64(1002), request[1]["toObjectId"])
+	require.Equal(t, 67, request[1]["definitionId"])
+}
+
+func TestProductsList(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{
+				"id":         "5",
+				"properties": map[string]interface{}{"name": "License", "price": "500.00", "hs_sku": "LIC-1"}}},
+		"paging": map[string]interface{}{"next": map[string]interface{}{"after": "5"}}}}
+	api := NewProducts(rest, MustNewModel(reflect.TypeOf(Product{})))
+
+	response, err := api.List(NewPage(0, 10), "name", "price", "hs_sku")
+	require.NoError(t, err)
+	require.Equal(t, "GET crm/v3/objects/products?hapikey=xyz&limit=10&properties=name%2Cprice%2Chs_sku", rest.LastRequest())
+	require.True(t, response.HasMore)
+	require.Equal(t, int64(5), response.Offset)
+
+	product := response.Data[0].(*Product)
+	require.Equal(t, int64(5), product.ID)
+	require.Equal(t, float64(500), product.Price)
+	require.Equal(t, "LIC-1", product.SKU)
+}
+
+func TestLineItemCreateForDealAssociationFails(t *testing.T) {
+	rest := &TestRest{
+		Responses: []map[string]interface{}{
+			map[string]interface{}{
+				"id":         "1001",
+				"properties": map[string]interface{}{"name": "Consulting"}}},
+		Errors: []error{nil, errors.New("association failed")}}
+	api := NewLineItems(rest, lineItemModel)
+
+	created, err := api.CreateForDeal(151088, &LineItem{Name: "Consulting", Quantity: 2})
+	require.Error(t, err)
+	require.Equal(t, 2, len(rest.requests))
+	require.Equal(t, int64(1001), created.(*LineItem).ID)
+}
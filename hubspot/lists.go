@@ -0,0 +1,277 @@
+package hubspot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// maximum number of contacts which can be added to or removed from a list in a single call
+const listMembershipBatchSize = 500
+
+// ListFilter - filter of an active contact list
+// filters in the same group are combined using AND, filter groups are combined using OR
+type ListFilter struct {
+	Operator     string      `json:"operator"`
+	Property     string      `json:"property,omitempty"`
+	Value        interface{} `json:"value,omitempty"`
+	Type         string      `json:"type,omitempty"`
+	FilterFamily string      `json:"filterFamily,omitempty"`
+}
+
+// ContactList - contact list in hubspot
+type ContactList struct {
+	ID        int64
+	Name      string
+	Dynamic   bool            // true for active lists which are maintained by hubspot using filters
+	Filters   [][]*ListFilter // filter groups of an active list
+	Size      int64           // number of contacts in the list
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ListMembershipResult - result of adding or removing contacts to a static list
+type ListMembershipResult struct {
+	Updated       []int64  // ids of contacts which were added or removed
+	Discarded     []int64  // ids of contacts which were already (not) part of the list
+	InvalidVids   []int64  // ids of contacts which don't exist
+	InvalidEmails []string // emails for which no contact exists
+}
+
+// IContactLists - interface for the hubspot contact lists api
+type IContactLists interface {
+	Create(list *ContactList) (*ContactList, error)
+	Update(id int64, list *ContactList) (*ContactList, error)
+	Delete(id int64) error
+	Get(id int64) (*ContactList, error)
+	List(page *Page) (*PageResponse, error)
+	AddContacts(id int64, vids []int64, emails ...string) (*ListMembershipResult, error)
+	RemoveContacts(id int64, vids []int64) (*ListMembershipResult, error)
+	Members(id int64, page *Page, props ...string) (*PageResponse, error)
+}
+
+// ContactLists - access to contact lists api of hubspot using rest
+type ContactLists struct {
+	contacts *Contacts   // contacts api used to convert list members
+	rest     IRestClient // client used to send requests
+}
+
+// NewContactLists - creates a new contact lists api
+//
+// **Parameters**
+//   rest : client used to send requests
+//   model: model used to convert list members
+func NewContactLists(rest IRestClient, model *Model) *ContactLists {
+	return &ContactLists{
+		contacts: NewContacts(rest, model),
+		rest:     rest}
+}
+
+func createListRequest(list *ContactList) map[string]interface{} {
+	request := map[string]interface{}{
+		"name":    list.Name,
+		"dynamic": list.Dynamic}
+
+	if list.Filters != nil {
+		request["filters"] = list.Filters
+	}
+
+	return request
+}
+
+func readListFilters(data interface{}) [][]*ListFilter {
+	groups, ok := data.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var filters [][]*ListFilter
+	for _, groupobj := range groups {
+		group, ok := groupobj.([]interface{})
+		if !ok {
+			continue
+		}
+
+		var groupfilters []*ListFilter
+		for _, filterobj := range group {
+			filter, ok := filterobj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			groupfilters = append(groupfilters, &ListFilter{
+				Operator:     cast.ToString(filter["operator"]),
+				Property:     cast.ToString(filter["property"]),
+				Value:        filter["value"],
+				Type:         cast.ToString(filter["type"]),
+				FilterFamily: cast.ToString(filter["filterFamily"])})
+		}
+		filters = append(filters, groupfilters)
+	}
+
+	return filters
+}
+
+func readContactList(response map[string]interface{}) (*ContactList, error) {
+	createdat, updatedat, err := readTimestamps(response, "createdAt", "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	list := &ContactList{
+		ID:        cast.ToInt64(response["listId"]),
+		Name:      cast.ToString(response["name"]),
+		Dynamic:   cast.ToBool(response["dynamic"]),
+		Filters:   readListFilters(response["filters"]),
+		CreatedAt: createdat,
+		UpdatedAt: updatedat}
+
+	metadata, ok := response["metaData"].(map[string]interface{})
+	if ok {
+		list.Size = cast.ToInt64(metadata["size"])
+	}
+
+	return list, nil
+}
+
+// Create - creates a new contact list
+func (api *ContactLists) Create(list *ContactList) (*ContactList, error) {
+	response, err := api.rest.Post("contacts/v1/lists", createListRequest(list))
+	if err != nil {
+		return nil, err
+	}
+
+	return readContactList(response)
+}
+
+// Update - updates name and filters of a contact list
+func (api *ContactLists) Update(id int64, list *ContactList) (*ContactList, error) {
+	response, err := api.rest.Post(fmt.Sprintf("contacts/v1/lists/%d", id), createListRequest(list))
+	if err != nil {
+		return nil, err
+	}
+
+	return readContactList(response)
+}
+
+// Delete - deletes a contact list
+func (api *ContactLists) Delete(id int64) error {
+	return api.rest.Delete(fmt.Sprintf("contacts/v1/lists/%d", id))
+}
+
+// Get - get a contact list by id
+func (api *ContactLists) Get(id int64) (*ContactList, error) {
+	response, err := api.rest.Get(fmt.Sprintf("contacts/v1/lists/%d", id))
+	if err != nil {
+		return nil, err
+	}
+
+	return readContactList(response)
+}
+
+// List - lists a page of contact lists
+func (api *ContactLists) List(page *Page) (*PageResponse, error) {
+	var parameters []*Parameter
+	if page != nil {
+		if page.Count > 0 {
+			parameters = append(parameters, NewParameter("count", fmt.Sprintf("%d", page.Count)))
+		}
+
+		if page.Offset > 0 {
+			parameters = append(parameters, NewParameter("offset", fmt.Sprintf("%d", page.Offset)))
+		}
+	}
+
+	response, err := api.rest.Get("contacts/v1/lists", parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := new(PageResponse)
+	pr.HasMore = cast.ToBool(response["has-more"])
+	if pr.HasMore {
+		pr.Offset = cast.ToInt64(response["offset"])
+	}
+
+	lists, ok := response["lists"].([]interface{})
+	if ok {
+		for _, listobj := range lists {
+			list, ok := listobj.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("Unexpected response structure from hubspot")
+			}
+
+			item, err := readContactList(list)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, item)
+		}
+	}
+
+	return pr, nil
+}
+
+func (result *ListMembershipResult) add(response map[string]interface{}) {
+	result.Updated = append(result.Updated, toInt64Slice(response["updated"])...)
+	result.Discarded = append(result.Discarded, toInt64Slice(response["discarded"])...)
+	result.InvalidVids = append(result.InvalidVids, toInt64Slice(response["invalidVids"])...)
+	result.InvalidEmails = append(result.InvalidEmails, cast.ToStringSlice(response["invalidEmails"])...)
+}
+
+func (api *ContactLists) changeMembership(address string, vids []int64, emails []string) (*ListMembershipResult, error) {
+	result := &ListMembershipResult{}
+
+	// hubspot only accepts a limited number of contacts per call, so bigger requests are split
+	for len(vids) > 0 || len(emails) > 0 {
+		request := make(map[string]interface{})
+
+		if len(vids) > 0 {
+			count := len(vids)
+			if count > listMembershipBatchSize {
+				count = listMembershipBatchSize
+			}
+
+			request["vids"] = vids[:count]
+			vids = vids[count:]
+		} else {
+			count := len(emails)
+			if count > listMembershipBatchSize {
+				count = listMembershipBatchSize
+			}
+
+			request["emails"] = emails[:count]
+			emails = emails[count:]
+		}
+
+		response, err := api.rest.Post(address, request)
+		if err != nil {
+			// batches sent before were already applied by hubspot
+			return result, err
+		}
+
+		result.add(response)
+	}
+
+	return result, nil
+}
+
+// AddContacts - adds contacts to a static list
+// contacts can be specified by id and/or email. Big numbers of contacts are sent in multiple calls.
+// If a call fails the result of the calls sent before is returned along with the error.
+func (api *ContactLists) AddContacts(id int64, vids []int64, emails ...string) (*ListMembershipResult, error) {
+	return api.changeMembership(fmt.Sprintf("contacts/v1/lists/%d/add", id), vids, emails)
+}
+
+// RemoveContacts - removes contacts from a static list
+func (api *ContactLists) RemoveContacts(id int64, vids []int64) (*ListMembershipResult, error) {
+	return api.changeMembership(fmt.Sprintf("contacts/v1/lists/%d/remove", id), vids, nil)
+}
+
+// Members - lists a page of contacts which are member of a list
+func (api *ContactLists) Members(id int64, page *Page, props ...string) (*PageResponse, error) {
+	return api.contacts.listContacts(fmt.Sprintf("contacts/v1/lists/%d/contacts/all", id), page, props)
+}
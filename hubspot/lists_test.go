@@ -0,0 +1,153 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const responseListGet string = `{
+	"listId": 1,
+	"name": "Active customers",
+	"dynamic": true,
+	"createdAt": 1461173216829,
+	"updatedAt": 1461173216829,
+	"filters": [
+	  [
+		{
+		  "filterFamily": "PropertyValue",
+		  "operator": "EQ",
+		  "property": "lifecyclestage",
+		  "type": "enumeration",
+		  "value": "customer"
+		}
+	  ]
+	],
+	"metaData": {
+	  "size": 13
+	}
+  }`
+
+const responseListMembers string = `{
+	"contacts": [
+	  {
+		"vid": 61574,
+		"properties": {
+		  "name": {"value": "Peter"},
+		  "email": {"value": "peter@lack.de"}
+		}
+	  }
+	],
+	"has-more": true,
+	"vid-offset": 61574
+  }`
+
+func TestContactListsInterfaceImpl(t *testing.T) {
+	var lists IContactLists = &ContactLists{}
+
+	if lists != nil {
+		return
+	}
+}
+
+func TestContactListCreate(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseListGet)}
+	api := NewContactLists(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	list, err := api.Create(&ContactList{
+		Name:    "Active customers",
+		Dynamic: true,
+		Filters: [][]*ListFilter{
+			[]*ListFilter{
+				&ListFilter{
+					FilterFamily: "PropertyValue",
+					Operator:     "EQ",
+					Property:     "lifecyclestage",
+					Type:         "enumeration",
+					Value:        "customer"}}}})
+	require.NoError(t, err)
+	require.Equal(t, "POST contacts/v1/lists?hapikey=xyz", rest.LastRequest())
+
+	request := rest.LastBody().(map[string]interface{})
+	require.Equal(t, "Active customers", request["name"])
+	require.Equal(t, true, request["dynamic"])
+	require.Equal(t, 1, len(request["filters"].([][]*ListFilter)))
+
+	require.Equal(t, int64(1), list.ID)
+	require.Equal(t, int64(13), list.Size)
+	require.Equal(t, 2016, list.CreatedAt.Year())
+	require.Equal(t, 1, len(list.Filters))
+	require.Equal(t, "lifecyclestage", list.Filters[0][0].Property)
+	require.Equal(t, "customer", list.Filters[0][0].Value)
+}
+
+func TestContactListAddContactsSplitsBatches(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"updated":     []interface{}{float64(1)},
+		"invalidVids": []interface{}{float64(2)}}}
+	api := NewContactLists(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	vids := make([]int64, 700)
+	for i := range vids {
+		vids[i] = int64(i + 1)
+	}
+
+	result, err := api.AddContacts(226, vids, "peter@lack.de")
+	require.NoError(t, err)
+	require.Equal(t, 3, len(rest.requests))
+	require.Equal(t, "POST contacts/v1/lists/226/add?hapikey=xyz", rest.LastRequest())
+
+	require.Equal(t, 500, len(rest.bodies[0].(map[string]interface{})["vids"].([]int64)))
+	require.Equal(t, 200, len(rest.bodies[1].(map[string]interface{})["vids"].([]int64)))
+	require.Equal(t, []string{"peter@lack.de"}, rest.bodies[2].(map[string]interface{})["emails"])
+
+	require.Equal(t, []int64{1, 1, 1}, result.Updated)
+	require.Equal(t, []int64{2, 2, 2}, result.InvalidVids)
+}
+
+func TestContactListAddContactsPartialFailure(t *testing.T) {
+	rest := &TestRest{
+		Response: map[string]interface{}{"updated": []interface{}{float64(1)}},
+		Errors:   []error{nil, errors.New("rate limit exceeded")}}
+	api := NewContactLists(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	vids := make([]int64, 700)
+	for i := range vids {
+		vids[i] = int64(i + 1)
+	}
+
+	result, err := api.AddContacts(226, vids)
+	require.Error(t, err)
+	require.Equal(t, 2, len(rest.requests))
+	require.NotNil(t, result)
+	require.Equal(t, []int64{1}, result.Updated)
+}
+
+func TestContactListRemoveContacts(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{}}
+	api := NewContactLists(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	_, err := api.RemoveContacts(226, []int64{3, 4})
+	require.NoError(t, err)
+	require.Equal(t, "POST contacts/v1/lists/226/remove?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, []int64{3, 4}, rest.LastBody().(map[string]interface{})["vids"])
+}
+
+func TestContactListMembers(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseListMembers)}
+	api := NewContactLists(rest, MustNewModel(reflect.TypeOf(Person{})))
+
+	response, err := api.Members(226, NewPage(100, 10), "email")
+	require.NoError(t, err)
+	require.Equal(t, "GET contacts/v1/lists/226/contacts/all?hapikey=xyz&count=10&vidOffset=100&property=email", rest.LastRequest())
+
+	require.True(t, response.HasMore)
+	require.Equal(t, int64(61574), response.Offset)
+	require.Equal(t, 1, len(response.Data))
+
+	person := response.Data[0].(*Person)
+	require.Equal(t, int64(61574), person.ID)
+	require.Equal(t, "peter@lack.de", person.EMail)
+}
@@ -0,0 +1,148 @@
+package hubspot
+
+import (
+	"encoding"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+var textmarshalertype reflect.Type = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+var textunmarshalertype reflect.Type = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+var hubspotmarshalertype reflect.Type = reflect.TypeOf((*HubspotMarshaler)(nil)).Elem()
+var hubspotunmarshalertype reflect.Type = reflect.TypeOf((*HubspotUnmarshaler)(nil)).Elem()
+
+// HubspotMarshaler - type which converts itself to a hubspot property value
+type HubspotMarshaler interface {
+	MarshalHubspot() (string, error)
+}
+
+// HubspotUnmarshaler - type which reads itself from a hubspot property value
+type HubspotUnmarshaler interface {
+	UnmarshalHubspot(value string) error
+}
+
+// Converter - converts values of a type from and to hubspot property values
+// used for types which can't implement HubspotMarshaler / HubspotUnmarshaler (eg. third-party types)
+type Converter struct {
+	ToHubspot   func(value interface{}) (string, error) // converts a field value to a property value
+	FromHubspot func(value string) (interface{}, error) // converts a property value to a field value
+}
+
+var converters = make(map[reflect.Type]*Converter)
+var convertersmutex sync.RWMutex
+
+// RegisterConverter - registers a converter for a type
+// the converter is used by all models for fields of the type and pointer fields to the type.
+// Converters take precedence over HubspotMarshaler and encoding.TextMarshaler implementations.
+func RegisterConverter(datatype reflect.Type, converter *Converter) {
+	convertersmutex.Lock()
+	defer convertersmutex.Unlock()
+
+	if converter == nil {
+		delete(converters, datatype)
+		return
+	}
+	converters[datatype] = converter
+}
+
+func getConverter(datatype reflect.Type) *Converter {
+	convertersmutex.RLock()
+	defer convertersmutex.RUnlock()
+	return converters[datatype]
+}
+
+// getInterface - get an interface implemented by a field or by a pointer to the field
+func getInterface(field reflect.Value, iface reflect.Type) interface{} {
+	if field.Type().Implements(iface) {
+		return field.Interface()
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(iface) {
+		return field.Addr().Interface()
+	}
+
+	return nil
+}
+
+// marshalField - converts a field using a registered converter or a marshaler implemented by its type
+// returns false if the field type has no custom conversion
+func marshalField(field reflect.Value) (string, bool, error) {
+	converter := getConverter(field.Type())
+	if converter != nil && converter.ToHubspot != nil {
+		value, err := converter.ToHubspot(field.Interface())
+		return value, true, err
+	}
+
+	marshaler, ok := getInterface(field, hubspotmarshalertype).(HubspotMarshaler)
+	if ok {
+		value, err := marshaler.MarshalHubspot()
+		return value, true, err
+	}
+
+	// time has its own conversion to unix milliseconds
+	if field.Type() == timetype {
+		return "", false, nil
+	}
+
+	textmarshaler, ok := getInterface(field, textmarshalertype).(encoding.TextMarshaler)
+	if ok {
+		value, err := textmarshaler.MarshalText()
+		return string(value), true, err
+	}
+
+	return "", false, nil
+}
+
+// unmarshalField - sets a field using a registered converter or an unmarshaler implemented by its type
+// returns false if the field type has no custom conversion
+func unmarshalField(field reflect.Value, value interface{}) (bool, error) {
+	converter := getConverter(field.Type())
+	if converter != nil && converter.FromHubspot != nil {
+		converted, err := converter.FromHubspot(cast.ToString(value))
+		if err != nil {
+			return true, err
+		}
+
+		if converted == nil {
+			field.Set(reflect.Zero(field.Type()))
+			return true, nil
+		}
+
+		convertedvalue := reflect.ValueOf(converted)
+		switch {
+		case convertedvalue.Type().AssignableTo(field.Type()):
+			field.Set(convertedvalue)
+		case convertedvalue.Kind() == field.Kind() && convertedvalue.Type().ConvertibleTo(field.Type()):
+			// named types with the same underlying type (eg. string based enums)
+			field.Set(convertedvalue.Convert(field.Type()))
+		default:
+			return true, errors.Errorf("Converter returned %s instead of %s", convertedvalue.Type(), field.Type())
+		}
+		return true, nil
+	}
+
+	if !field.CanAddr() {
+		return false, nil
+	}
+
+	unmarshaler, ok := field.Addr().Interface().(HubspotUnmarshaler)
+	if ok {
+		return true, unmarshaler.UnmarshalHubspot(cast.ToString(value))
+	}
+
+	if field.Type() == timetype || !field.Addr().Type().Implements(textunmarshalertype) {
+		return false, nil
+	}
+
+	text := cast.ToString(value)
+	if len(text) == 0 {
+		// most text formats have no representation for empty values
+		field.Set(reflect.Zero(field.Type()))
+		return true, nil
+	}
+
+	return true, field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+}
@@ -0,0 +1,167 @@
+package hubspot
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+	"github.com/stretchr/testify/require"
+)
+
+type Money struct {
+	Cents int64
+}
+
+func (money Money) MarshalHubspot() (string, error) {
+	return fmt.Sprintf("%d.%02d", money.Cents/100, money.Cents%100), nil
+}
+
+func (money *Money) UnmarshalHubspot(value string) error {
+	if len(value) == 0 {
+		money.Cents = 0
+		return nil
+	}
+
+	parts := strings.SplitN(value, ".", 2)
+	money.Cents = cast.ToInt64(parts[0]) * 100
+	if len(parts) > 1 {
+		money.Cents += cast.ToInt64((parts[1] + "00")[:2])
+	}
+	return nil
+}
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
+
+func (priority Priority) MarshalText() ([]byte, error) {
+	switch priority {
+	case PriorityLow:
+		return []byte("LOW"), nil
+	case PriorityHigh:
+		return []byte("HIGH"), nil
+	}
+	return nil, errors.Errorf("Invalid priority %d", priority)
+}
+
+func (priority *Priority) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "LOW":
+		*priority = PriorityLow
+	case "HIGH":
+		*priority = PriorityHigh
+	default:
+		return errors.Errorf("Invalid priority '%s'", text)
+	}
+	return nil
+}
+
+type MarshaledDeal struct {
+	ID       int64 `hubspot:"id"`
+	Amount   Money
+	Discount *Money
+	Priority Priority
+	Website  url.URL
+}
+
+func registerURLConverter() {
+	RegisterConverter(reflect.TypeOf(url.URL{}), &Converter{
+		ToHubspot: func(value interface{}) (string, error) {
+			address := value.(url.URL)
+			return address.String(), nil
+		},
+		FromHubspot: func(value string) (interface{}, error) {
+			address, err := url.Parse(value)
+			if err != nil {
+				return nil, err
+			}
+			return *address, nil
+		}})
+}
+
+func TestMarshalWrite(t *testing.T) {
+	registerURLConverter()
+	defer RegisterConverter(reflect.TypeOf(url.URL{}), nil)
+
+	rest := &TestRest{Response: map[string]interface{}{"id": "512"}}
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(MarshaledDeal{})))
+
+	website, _ := url.Parse("https://www.vertical.de/shop")
+	_, err := api.Create(&MarshaledDeal{
+		Amount:   Money{Cents: 12050},
+		Discount: &Money{},
+		Priority: PriorityHigh,
+		Website:  *website})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"amount":   "120.50",
+		"discount": "0.00",
+		"priority": "HIGH",
+		"website":  "https://www.vertical.de/shop"}, request)
+}
+
+func TestMarshalRead(t *testing.T) {
+	registerURLConverter()
+	defer RegisterConverter(reflect.TypeOf(url.URL{}), nil)
+
+	rest := &TestRest{Response: readTestResponse(`{
+		"id": "512",
+		"properties": {
+		  "amount": "99.9",
+		  "discount": "5",
+		  "priority": "HIGH",
+		  "website": "https://www.vertical.de/shop"
+		}
+	  }`)}
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(MarshaledDeal{})))
+
+	entity, err := api.Get(512)
+	require.NoError(t, err)
+
+	deal := entity.(*MarshaledDeal)
+	require.Equal(t, int64(9990), deal.Amount.Cents)
+	require.NotNil(t, deal.Discount)
+	require.Equal(t, int64(500), deal.Discount.Cents)
+	require.Equal(t, PriorityHigh, deal.Priority)
+	require.Equal(t, "www.vertical.de", deal.Website.Host)
+}
+
+func TestMarshalErrorFailsRequest(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "512"}}
+	api := NewObjects(rest, "deals", MustNewModel(reflect.TypeOf(MarshaledDeal{})))
+
+	_, err := api.Create(&MarshaledDeal{Amount: Money{Cents: 100}, Priority: Priority(7)})
+	require.Error(t, err)
+	require.Empty(t, rest.requests)
+
+	conversion, ok := err.(*ConversionError)
+	require.True(t, ok)
+	require.Equal(t, "Priority", conversion.Field)
+	require.Equal(t, "priority", conversion.Property)
+}
+
+func TestConverterWrongType(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(url.URL{}), &Converter{
+		FromHubspot: func(value string) (interface{}, error) {
+			return value, nil
+		}})
+	defer RegisterConverter(reflect.TypeOf(url.URL{}), nil)
+
+	rest := &TestRest{Response: map[string]interface{}{
+		"id":         "512",
+		"properties": map[string]interface{}{"website": "https://www.vertical.de/shop"}}}
+	api := NewObjects(rest, "deals", MustNewModelWithOptions(reflect.TypeOf(MarshaledDeal{}), Options{Strict: true}))
+
+	_, err := api.Get(512)
+	require.Error(t, err)
+	require.Equal(t, "Website", err.(ConversionErrors)[0].Field)
+}
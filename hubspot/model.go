@@ -1,46 +1,172 @@
 package hubspot
 
 import (
-	"log"
 	"reflect"
 	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 )
 
 // Model - model for hubspot entity mapping
 type Model struct {
-	id         *ModelProperty
-	deleted    *ModelProperty
-	companies  *ModelProperty // companies linked to data (used for deals)
-	contacts   *ModelProperty // contacts linked to data (used for deals)
-	properties map[string]*ModelProperty
-	datatype   reflect.Type
+	id          *ModelProperty
+	deleted     *ModelProperty
+	companies   *ModelProperty   // companies linked to data (used for deals)
+	contacts    *ModelProperty   // contacts linked to data (used for deals)
+	owneremails []*ModelProperty // fields receiving owner emails (see OwnerCache)
+	snapshot    *ModelProperty   // field receiving the snapshot of loaded entities (see Snapshot)
+	extra       *ModelProperty   // field receiving properties which are not mapped to other fields
+	history     *ModelProperty   // field receiving the history of properties (see PropertyHistory)
+	properties  map[string]*ModelProperty
+	byname      map[string]*ModelProperty // properties by hubspot name
+	fieldorder  []*ModelProperty          // properties in order of struct fields
+	datatype    reflect.Type
+	options     Options // options used when sending data to hubspot
 }
 
 // ModelProperty - property in a hubspot model
 type ModelProperty struct {
-	StructField string
-	HubspotName string
-	NoExport    bool
+	StructField   string
+	HubspotName   string
+	NoExport      bool
+	ReadOnly      bool           // property is never sent to hubspot
+	CreateOnly    bool           // property is only sent when entities are created
+	Calculated    bool           // property is owned and calculated by hubspot, it is never sent like ReadOnly
+	OwnerProperty string         // hubspot property containing the owner id for owner email fields
+	Date          bool           // property is a date property which only accepts midnight UTC
+	Options       []string       // allowed values of enumeration properties (see Model.Validate)
+	path          []string       // names of fields leading to the property field (more than one for nested structs)
+	location      *time.Location // location of times read from hubspot (see Options.Location)
 }
 
 // NewModel - creates a new model for an entity
-// use tag values for 'hubspot' to specify hubspot properties. If no hubspot tag is specified
-// the field name in all lower cases is used as fieldname on hubspot as default
+// use tag values for 'hubspot' to specify hubspot properties. If no name is specified in the hubspot tag
+// the field name in all lower cases is used as fieldname on hubspot as default (see Options.Naming)
 //     name=<string> - specify hubspot field name
 //     id            - transfer hubspot entity id to this field
 //     deleted       - transfer deleted flag to this field
 //     noexport      - don't export this field to hubspot on create/update
-func NewModel(entitytype reflect.Type) *Model {
+//     readonly      - property is read from hubspot but never written
+//     createonly    - property is only sent by requests which create entities, not by updates or requests which create
+//                     or update entities (eg. initial lifecycle stage)
+//     calculated    - property is calculated by hubspot. It is never written like readonly and marks the property
+//                     as owned by hubspot for code inspecting the model
+//     owneremail    - field receives the email of the owner in 'hubspot_owner_id' when resolved using an OwnerCache
+//     owneremail=<string> - same as owneremail but uses the specified hubspot property as owner id
+//     snapshot      - field of type Snapshot which tracks changes of loaded entities
+//     date          - property is a date property, times are sent as midnight UTC of their date
+//     datetime      - property is a datetime property (default for time fields, except fields of type Date)
+//     options=<a|b> - allowed values of an enumeration property separated by '|' (default for Enum types are their options)
+//     prefix=<string> - maps the fields of a nested struct using the prefix for their hubspot names
+//     extra         - map field with string keys receiving all properties not mapped to other fields. Updates of entities
+//                     with a snapshot only send added or changed entries. Properties owned by hubspot (eg. hs_object_id) are never sent
+//     history       - field of type PropertyHistory receiving versions of properties if they are contained in responses
+//
+// fields of anonymous embedded structs are mapped like fields of the entity. Pointers to nested structs
+// are allocated when reading entities.
+//
+// nil pointers and zero values are not sent to hubspot. Use NewModelWithOptions to change this behavior.
+//
+// returns an error if a tag is used on a field of an unsupported type
+func NewModel(entitytype reflect.Type) (*Model, error) {
+	return NewModelWithOptions(entitytype, Options{})
+}
+
+// NewModelWithOptions - creates a new model for an entity using custom options
+// see NewModel for a description of supported tags
+func NewModelWithOptions(entitytype reflect.Type, options Options) (*Model, error) {
 	model := &Model{
 		datatype:   entitytype,
-		properties: make(map[string]*ModelProperty)}
+		properties: make(map[string]*ModelProperty),
+		byname:     make(map[string]*ModelProperty),
+		options:    options}
+
+	err := model.addFields(entitytype, nil, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// MustNewModel - creates a new model for an entity like NewModel but panics if the model is invalid
+// useful to initialize package level model variables
+func MustNewModel(entitytype reflect.Type) *Model {
+	return MustNewModelWithOptions(entitytype, Options{})
+}
+
+// MustNewModelWithOptions - creates a new model for an entity like NewModelWithOptions but panics if the model is invalid
+func MustNewModelWithOptions(entitytype reflect.Type, options Options) *Model {
+	model, err := NewModelWithOptions(entitytype, options)
+	if err != nil {
+		panic(err)
+	}
+
+	return model
+}
+
+// isNestedStruct - determines whether fields of a type are mapped to hubspot properties themselves
+// types which are converted to a single property value (eg. time.Time, Date, nullable types) are no nested structs
+func isNestedStruct(datatype reflect.Type) bool {
+	if datatype.Kind() == reflect.Ptr {
+		datatype = datatype.Elem()
+	}
+
+	if datatype.Kind() != reflect.Struct || datatype == timetype || datatype == snapshottype || getConverter(datatype) != nil {
+		return false
+	}
+
+	pointer := reflect.PtrTo(datatype)
+	for _, iface := range []reflect.Type{hubspotmarshalertype, hubspotunmarshalertype, textmarshalertype, textunmarshalertype, valuertype, scannertype} {
+		if datatype.Implements(iface) || pointer.Implements(iface) {
+			return false
+		}
+	}
+
+	return true
+}
 
-	for i := 0; i < entitytype.NumField(); i++ {
-		field := entitytype.Field(i)
+// addFields - adds properties for the fields of a struct type
+//
+// **Parameters**
+//   datatype   : struct type containing the fields
+//   path       : names of fields leading to the struct from the entity
+//   prefix     : prefix of hubspot names of the fields
+//   fieldprefix: prefix of struct field names of the fields (used for named nested structs)
+func (mdl *Model) addFields(datatype reflect.Type, path []string, prefix string, fieldprefix string) error {
+	for i := 0; i < datatype.NumField(); i++ {
+		field := datatype.Field(i)
+		fieldpath := append(append([]string{}, path...), field.Name)
 
 		hubspot := field.Tag.Get("hubspot")
+		if isNestedStruct(field.Type) {
+			// anonymous structs are flattened, named structs are only mapped when a prefix is specified
+			nestedprefix, ok := getPrefix(hubspot)
+			if field.Anonymous && (ok || len(hubspot) == 0) {
+				err := mdl.addFields(derefType(field.Type), fieldpath, prefix+nestedprefix, fieldprefix)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			if ok {
+				err := mdl.addFields(derefType(field.Type), fieldpath, prefix+nestedprefix, fieldprefix+field.Name+".")
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
 		property := &ModelProperty{
-			StructField: field.Name}
+			StructField: fieldprefix + field.Name,
+			Date:        field.Type == datetype || field.Type == reflect.PtrTo(datetype),
+			Options:     getEnumOptions(field.Type),
+			path:        fieldpath,
+			location:    mdl.options.getLocation()}
 
 		hubspotprop := false
 
@@ -49,29 +175,93 @@ func NewModel(entitytype reflect.Type) *Model {
 				property.HubspotName = attr[5:]
 				continue
 			}
+			if strings.HasPrefix(attr, "options=") {
+				property.Options = strings.Split(attr[8:], "|")
+				continue
+			}
+
+			if attr == "owneremail" || strings.HasPrefix(attr, "owneremail=") {
+				if field.Type.Kind() != reflect.String {
+					return errors.Errorf("Owner email field '%s' must be of type 'string'", property.StructField)
+				}
+
+				property.OwnerProperty = defaultOwnerProperty
+				if attr != "owneremail" {
+					property.OwnerProperty = attr[11:]
+				}
+
+				mdl.owneremails = append(mdl.owneremails, property)
+				hubspotprop = true
+				continue
+			}
+
+			if attr == "history" {
+				if field.Type != historytype {
+					return errors.Errorf("History field '%s' must be of type 'PropertyHistory'", property.StructField)
+				}
+
+				mdl.history = property
+				hubspotprop = true
+				continue
+			}
+
+			if attr == "extra" {
+				if !isExtraType(field.Type) {
+					return errors.Errorf("Extra field '%s' must be a map with string keys", property.StructField)
+				}
+
+				mdl.extra = property
+				hubspotprop = true
+				continue
+			}
+
+			if attr == "snapshot" {
+				if field.Type != snapshottype {
+					return errors.Errorf("Snapshot field '%s' must be of type 'Snapshot'", property.StructField)
+				}
+
+				mdl.snapshot = property
+				hubspotprop = true
+				continue
+			}
+
+			if attr == "date" || attr == "datetime" {
+				if !isDateType(field.Type) {
+					return errors.Errorf("Date field '%s' must be of type 'time.Time', 'sql.NullTime' or 'Date'", property.StructField)
+				}
+
+				property.Date = attr == "date"
+				continue
+			}
 
 			switch attr {
 			case "id":
-				model.id = property
+				mdl.id = property
 				property.NoExport = true
 			case "deleted":
-				model.deleted = property
+				mdl.deleted = property
 				property.NoExport = true
 			case "noexport":
 				property.NoExport = true
+			case "readonly":
+				property.ReadOnly = true
+			case "createonly":
+				property.CreateOnly = true
+			case "calculated":
+				property.Calculated = true
 			case "contacts":
 				if field.Type != reflect.TypeOf([]int64{}) {
-					log.Panicf("Deal Contacts field must be of type '[]int64'")
+					return errors.Errorf("Deal Contacts field '%s' must be of type '[]int64'", property.StructField)
 				}
 
-				model.contacts = property
+				mdl.contacts = property
 				property.NoExport = true
 			case "companies":
 				if field.Type != reflect.TypeOf([]int64{}) {
-					log.Panicf("Deal Companies field must be of type '[]int64'")
+					return errors.Errorf("Deal Companies field '%s' must be of type '[]int64'", property.StructField)
 				}
 
-				model.companies = property
+				mdl.companies = property
 				property.NoExport = true
 			}
 		}
@@ -81,13 +271,39 @@ func NewModel(entitytype reflect.Type) *Model {
 		}
 
 		if len(property.HubspotName) == 0 {
-			property.HubspotName = strings.ToLower(field.Name)
+			property.HubspotName = mdl.options.getNaming()(field.Name)
+			if len(property.HubspotName) == 0 {
+				// naming strategy doesn't map the field
+				continue
+			}
 		}
+		property.HubspotName = prefix + property.HubspotName
 
-		model.properties[field.Name] = property
+		mdl.properties[property.StructField] = property
+		if _, ok := mdl.byname[property.HubspotName]; !ok {
+			mdl.byname[property.HubspotName] = property
+		}
+		mdl.fieldorder = append(mdl.fieldorder, property)
 	}
 
-	return model
+	return nil
+}
+
+// getPrefix - get the prefix specified in the hubspot tag of a nested struct
+func getPrefix(tag string) (string, bool) {
+	for _, attr := range strings.Split(tag, ",") {
+		if strings.HasPrefix(attr, "prefix=") {
+			return attr[7:], true
+		}
+	}
+	return "", false
+}
+
+func derefType(datatype reflect.Type) reflect.Type {
+	if datatype.Kind() == reflect.Ptr {
+		return datatype.Elem()
+	}
+	return datatype
 }
 
 // GetProperty - get property of model
@@ -95,6 +311,10 @@ func (mdl *Model) GetProperty(name string) *ModelProperty {
 	return mdl.properties[name]
 }
 
+func (mdl *Model) getPropertyByHubspotName(name string) *ModelProperty {
+	return mdl.byname[name]
+}
+
 // GetID - get id of an entity
 func (mdl *Model) GetID(entity interface{}) interface{} {
 	if mdl.id == nil {
@@ -123,23 +343,83 @@ func (mdl *Model) GetCompanies(entity interface{}) []int64 {
 }
 
 // SetValue - set value from a json response to an entity which is based on this model
-func (prop *ModelProperty) SetValue(data map[string]interface{}, valuename string, entity reflect.Value) {
+// returns a ConversionError if the value can't be converted to the type of the field
+func (prop *ModelProperty) SetValue(data map[string]interface{}, valuename string, entity reflect.Value) error {
 	value, ok := data[valuename]
 	if !ok {
-		return
+		return nil
 	}
 
 	name := entity.Type().Name()
 	if len(name) == 0 {
-		return
+		return nil
 	}
 
-	field := entity.FieldByName(prop.StructField)
+	field := prop.field(entity, true)
 	if !field.IsValid() {
-		return
+		return nil
+	}
+
+	fieldvalue := value
+	if prop.Date && value != nil {
+		// dates are stored as midnight UTC and must not be converted to another location
+		date, err := ParseDate(cast.ToString(value))
+		if err == nil && !date.IsZero() {
+			fieldvalue = date.String()
+		}
+	}
+
+	err := setFieldValue(field, fieldvalue, prop.location)
+	if err != nil {
+		return &ConversionError{
+			Field:    prop.StructField,
+			Property: prop.HubspotName,
+			Value:    value,
+			Err:      err}
+	}
+
+	return nil
+}
+
+// writeMode - type of request entities are sent to hubspot with
+type writeMode int
+
+const (
+	writeCreate writeMode = iota // entities are created in hubspot
+	writeUpdate                  // existing entities are updated in hubspot
+	writeUpsert                  // entities are created or updated depending on whether they already exist
+)
+
+// writable - determines whether the property is sent to hubspot in requests of a write mode
+func (prop *ModelProperty) writable(mode writeMode) bool {
+	if prop.NoExport || prop.ReadOnly || prop.Calculated {
+		return false
+	}
+
+	return mode == writeCreate || !prop.CreateOnly
+}
+
+// hubspotValue - converts a value of the property to the format expected by hubspot
+// times are sent as unix time in milliseconds
+func (prop *ModelProperty) hubspotValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+
+		if prop.Date {
+			return toHubspotDate(v)
+		}
+		return toHubspotString(v)
+	}
+
+	refvalue := reflect.ValueOf(value)
+	if refvalue.Kind() == reflect.Slice && refvalue.Type().Elem().Kind() == reflect.String {
+		return joinMultiValue(refvalue)
 	}
 
-	field.Set(reflect.ValueOf(convert(value, field.Type())))
+	return value
 }
 
 func (prop *ModelProperty) getHubspotValue(entity interface{}) interface{} {
@@ -151,12 +431,73 @@ func (prop *ModelProperty) getHubspotValue(entity interface{}) interface{} {
 	return prop.GetValue(refvalue)
 }
 
+// exportValue - get the value of a field to send to hubspot
+// returns false if the field should not be sent and an error if the value of the field can't be converted
+func (mdl *Model) exportValue(field reflect.Value) (interface{}, bool, error) {
+	value, isnil, explicit, err := getNullableValue(field)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if isnil {
+		if mdl.options.IgnoreNil || !mdl.options.ClearNil {
+			return nil, false, nil
+		}
+		// an empty string clears a property in hubspot
+		return "", true, nil
+	}
+
+	if explicit || !field.IsZero() {
+		return value, true, nil
+	}
+
+	switch mdl.options.ZeroValues {
+	case ZeroValueSend:
+		return value, true, nil
+	case ZeroValueClear:
+		return "", true, nil
+	}
+
+	return nil, false, nil
+}
+
 // GetValue - get value of a property
 func (prop *ModelProperty) GetValue(entity reflect.Value) interface{} {
-	field := entity.FieldByName(prop.StructField)
+	field := prop.field(entity, false)
 	if !field.IsValid() {
 		return nil
 	}
 
 	return field.Interface()
 }
+
+// field - get the field of the property in an entity
+// fields are looked up by name so entities of other types containing the same fields can be used.
+// Nil pointers to nested structs are allocated if allocate is true, otherwise an invalid value is returned.
+func (prop *ModelProperty) field(entity reflect.Value, allocate bool) reflect.Value {
+	current := entity
+	for index, name := range prop.path {
+		if index > 0 {
+			if current.Kind() == reflect.Ptr {
+				if current.IsNil() {
+					if !allocate || !current.CanSet() {
+						return reflect.Value{}
+					}
+					current.Set(reflect.New(current.Type().Elem()))
+				}
+				current = current.Elem()
+			}
+
+			if current.Kind() != reflect.Struct {
+				return reflect.Value{}
+			}
+		}
+
+		current = current.FieldByName(name)
+		if !current.IsValid() {
+			return current
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,99 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type CountedCompany struct {
+	ID        int64  `hubspot:"id"`
+	Name      string `hubspot:"name=name"`
+	Employees int    `hubspot:"name=numberofemployees"`
+}
+
+func TestNewModelInvalidTag(t *testing.T) {
+	type InvalidDeal struct {
+		Contacts []string `hubspot:"contacts"`
+	}
+
+	_, err := NewModel(reflect.TypeOf(InvalidDeal{}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Contacts")
+
+	require.Panics(t, func() { MustNewModel(reflect.TypeOf(InvalidDeal{})) })
+}
+
+func TestConversionErrorSkipsValue(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":              "vertical GmbH",
+			"numberofemployees": "many"}}}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(CountedCompany{})))
+
+	result, err := api.Get(512)
+	require.NoError(t, err)
+
+	company := result.(*CountedCompany)
+	require.Equal(t, "vertical GmbH", company.Name)
+	require.Equal(t, 0, company.Employees)
+}
+
+func TestConversionErrorStrict(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":              "vertical GmbH",
+			"numberofemployees": "many"}}}
+	api := NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(CountedCompany{}), Options{Strict: true}))
+
+	result, err := api.Get(512)
+	require.Error(t, err)
+	require.Nil(t, result)
+
+	errs, ok := err.(ConversionErrors)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	require.Equal(t, "Employees", errs[0].Field)
+	require.Equal(t, "numberofemployees", errs[0].Property)
+	require.Equal(t, "many", errs[0].Value)
+	require.Error(t, errors.Cause(errs[0]))
+}
+
+func TestConversionStrictEmptyValue(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":              "vertical GmbH",
+			"numberofemployees": ""}}}
+	api := NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(CountedCompany{}), Options{Strict: true}))
+
+	result, err := api.Get(512)
+	require.NoError(t, err)
+	require.Equal(t, 0, result.(*CountedCompany).Employees)
+}
+
+func TestConversionStrictCreateKeepsID(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":              "vertical GmbH",
+			"numberofemployees": "many"}}}
+	api := NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(CountedCompany{}), Options{Strict: true}))
+
+	// the company already exists in hubspot, so it is returned along with the errors
+	result, err := api.Create(&CountedCompany{Name: "vertical GmbH"})
+	require.Error(t, err)
+	require.Len(t, err.(ConversionErrors), 1)
+
+	company := result.(*CountedCompany)
+	require.Equal(t, int64(512), company.ID)
+	require.Equal(t, "vertical GmbH", company.Name)
+
+	result, err = api.Update(512, &CountedCompany{Name: "vertical GmbH"})
+	require.Error(t, err)
+	require.Equal(t, int64(512), result.(*CountedCompany).ID)
+}
@@ -0,0 +1,51 @@
+package hubspot
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy - determines the hubspot name of fields which don't specify a name in their tag
+// returns an empty string if the field is not mapped to a hubspot property
+type NamingStrategy func(fieldname string) string
+
+// LowerCaseNaming - uses the field name in all lower cases (default)
+//   FirstName -> firstname
+func LowerCaseNaming(fieldname string) string {
+	return strings.ToLower(fieldname)
+}
+
+// SnakeCaseNaming - converts the field name to snake case
+//   FirstName -> first_name
+//   CompanyID -> company_id
+func SnakeCaseNaming(fieldname string) string {
+	runes := []rune(fieldname)
+
+	var builder strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			// acronyms are kept together, a new word starts at the last upper case letter before lower case letters
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) ||
+				(unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				builder.WriteRune('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+
+	return builder.String()
+}
+
+// ExplicitNaming - only maps fields which specify a name in their tag
+func ExplicitNaming(fieldname string) string {
+	return ""
+}
+
+// getNaming - get naming strategy of options
+func (options Options) getNaming() NamingStrategy {
+	if options.Naming == nil {
+		return LowerCaseNaming
+	}
+	return options.Naming
+}
@@ -0,0 +1,54 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type NamedContact struct {
+	ID          int64 `hubspot:"id"`
+	FirstName   string
+	CompanyID   int64
+	HTTPAddress string
+	Address2    string
+	Email       string `hubspot:"name=email"`
+}
+
+func TestSnakeCaseNaming(t *testing.T) {
+	require.Equal(t, "first_name", SnakeCaseNaming("FirstName"))
+	require.Equal(t, "company_id", SnakeCaseNaming("CompanyID"))
+	require.Equal(t, "http_address", SnakeCaseNaming("HTTPAddress"))
+	require.Equal(t, "address2", SnakeCaseNaming("Address2"))
+	require.Equal(t, "email", SnakeCaseNaming("Email"))
+}
+
+func TestModelNaming(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(NamedContact{}))
+	require.Equal(t, "firstname", model.GetProperty("FirstName").HubspotName)
+
+	model = MustNewModelWithOptions(reflect.TypeOf(NamedContact{}), Options{Naming: SnakeCaseNaming})
+	require.Equal(t, "first_name", model.GetProperty("FirstName").HubspotName)
+	require.Equal(t, "company_id", model.GetProperty("CompanyID").HubspotName)
+	require.Equal(t, "email", model.GetProperty("Email").HubspotName)
+}
+
+func TestModelExplicitNaming(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"vid": 61574,
+		"properties": map[string]interface{}{
+			"firstname": map[string]interface{}{"value": "Max"},
+			"email":     map[string]interface{}{"value": "max@example.com"}}}}
+	model := MustNewModelWithOptions(reflect.TypeOf(NamedContact{}), Options{Naming: ExplicitNaming})
+	require.Nil(t, model.GetProperty("FirstName"))
+
+	api := NewContacts(rest, model)
+	result, err := api.GetByID(61574)
+	require.NoError(t, err)
+
+	contact := result.(*NamedContact)
+	require.Equal(t, int64(61574), contact.ID)
+	require.Equal(t, "max@example.com", contact.Email)
+	require.Empty(t, contact.FirstName)
+}
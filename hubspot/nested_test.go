@@ -0,0 +1,78 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Audit struct {
+	CreatedAt time.Time `hubspot:"name=createdate"`
+	Owner     int64     `hubspot:"name=hubspot_owner_id"`
+}
+
+type Address struct {
+	Street string
+	City   string
+	Zip    string `hubspot:"name=postal_code"`
+}
+
+type NestedCompany struct {
+	Audit
+	ID       int64 `hubspot:"id"`
+	Name     string
+	Billing  Address  `hubspot:"prefix=billing_"`
+	Shipping *Address `hubspot:"prefix=shipping_"`
+}
+
+func TestNestedModel(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(NestedCompany{}))
+
+	require.Equal(t, "createdate", model.GetProperty("CreatedAt").HubspotName)
+	require.Equal(t, "hubspot_owner_id", model.GetProperty("Owner").HubspotName)
+	require.Equal(t, "billing_street", model.GetProperty("Billing.Street").HubspotName)
+	require.Equal(t, "shipping_postal_code", model.GetProperty("Shipping.Zip").HubspotName)
+	require.Nil(t, model.GetProperty("Billing"))
+	require.Nil(t, model.GetProperty("Audit"))
+}
+
+func TestNestedRead(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(NestedCompany{}))
+	result, err := objectToEntity(map[string]interface{}{
+		"id": "512",
+		"properties": map[string]interface{}{
+			"name":                 "vertical GmbH",
+			"createdate":           "2019-10-30T03:30:17.883Z",
+			"hubspot_owner_id":     "41629779",
+			"billing_street":       "Hauptstraße 1",
+			"billing_city":         "Berlin",
+			"shipping_postal_code": "10115"}}, model)
+	require.NoError(t, err)
+	company := result.(*NestedCompany)
+
+	require.Equal(t, int64(512), company.ID)
+	require.Equal(t, 2019, company.CreatedAt.Year())
+	require.Equal(t, int64(41629779), company.Owner)
+	require.Equal(t, "Berlin", company.Billing.City)
+	require.NotNil(t, company.Shipping)
+	require.Equal(t, "10115", company.Shipping.Zip)
+}
+
+func TestNestedWrite(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "512"}}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(NestedCompany{})))
+
+	company := &NestedCompany{Name: "vertical GmbH", Billing: Address{City: "Berlin"}}
+	company.Owner = 41629779
+
+	_, err := api.Create(company)
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"name":             "vertical GmbH",
+		"hubspot_owner_id": int64(41629779),
+		"billing_city":     "Berlin"}, request)
+}
@@ -0,0 +1,277 @@
+package hubspot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// maximum number of objects which can be read in a single batch call
+const batchReadSize = 100
+
+// MergeResult - result of merging two objects in hubspot
+type MergeResult struct {
+	Entity    interface{} // object which survived the merge
+	MergedIDs []int64     // ids of all objects which were ever absorbed by the surviving object
+}
+
+// IObjects - interface for the generic crm objects api
+type IObjects interface {
+	Create(object interface{}) (interface{}, error)
+	Update(id int64, object interface{}) (interface{}, error)
+	Get(id int64, props ...string) (interface{}, error)
+	GetWithHistory(id int64, props ...string) (interface{}, error)
+	List(page *Page, props ...string) (*PageResponse, error)
+	BatchRead(ids []int64, props ...string) ([]interface{}, error)
+	Delete(id int64) error
+	Merge(primaryid int64, mergeid int64) (*MergeResult, error)
+	Query() IQuery
+}
+
+// Objects - access to crm objects of any type using the v3 objects api
+type Objects struct {
+	objecttype string      // type of objects to access (eg. contacts, companies, line_items)
+	model      *Model      // model used to serialize / deserialize data
+	rest       IRestClient // client used to send requests
+}
+
+// NewObjects - creates a new objects api
+//
+// **Parameters**
+//   rest      : client used to send requests
+//   objecttype: type of objects to access (eg. contacts, companies, deals, tickets, line_items)
+//   model     : model used to serialize / deserialize data
+func NewObjects(rest IRestClient, objecttype string, model *Model) *Objects {
+	return &Objects{
+		objecttype: objecttype,
+		model:      model,
+		rest:       rest}
+}
+
+func (api *Objects) getPropertyNames(props []string) []string {
+	if len(props) == 0 {
+		for _, prop := range api.model.properties {
+			props = append(props, prop.HubspotName)
+		}
+	}
+
+	return props
+}
+
+func (api *Objects) getPropertyParameters(props []string) []*Parameter {
+	return []*Parameter{NewParameter("properties", strings.Join(api.getPropertyNames(props), ","))}
+}
+
+func (api *Objects) convertResults(response map[string]interface{}) ([]interface{}, error) {
+	var entities []interface{}
+
+	results, ok := response["results"].([]interface{})
+	if ok {
+		for _, obj := range results {
+			objdata, ok := obj.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("Unexpected response structure from hubspot")
+			}
+
+			entity, err := objectToEntity(objdata, api.model)
+			if err != nil {
+				return nil, err
+			}
+
+			entities = append(entities, entity)
+		}
+	}
+
+	return entities, nil
+}
+
+// create - creates a new object and returns the created entity along with its id
+// the id is returned even if the entity can't be converted since the object already exists in hubspot
+func (api *Objects) create(object interface{}) (interface{}, int64, error) {
+	request, err := createObjectRequest(object, api.model, writeCreate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response, err := api.rest.Post(fmt.Sprintf("crm/v3/objects/%s", api.objecttype), request)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entity, err := objectToEntity(response, api.model)
+	return entity, cast.ToInt64(response["id"]), err
+}
+
+// Create - creates a new object in hubspot
+func (api *Objects) Create(object interface{}) (interface{}, error) {
+	entity, _, err := api.create(object)
+	return entity, err
+}
+
+// Update - updates properties of an object in hubspot
+func (api *Objects) Update(id int64, object interface{}) (interface{}, error) {
+	rest, err := getRestV3(api.rest)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := createObjectRequest(object, api.model, writeUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := rest.Patch(fmt.Sprintf("crm/v3/objects/%s/%d", api.objecttype, id), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return objectToEntity(response, api.model)
+}
+
+// Get - get an object by id
+// if no properties are specified all properties of the model are returned
+func (api *Objects) Get(id int64, props ...string) (interface{}, error) {
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/objects/%s/%d", api.objecttype, id), api.getPropertyParameters(props)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return readEntity(objectToEntity(response, api.model))
+}
+
+// GetWithHistory - get an object by id including the history of its properties
+// the history is transferred to the history field of the model (see PropertyHistory).
+// If no properties are specified the history of all properties of the model is returned.
+func (api *Objects) GetWithHistory(id int64, props ...string) (interface{}, error) {
+	names := strings.Join(api.getPropertyNames(props), ",")
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/objects/%s/%d", api.objecttype, id),
+		NewParameter("properties", names),
+		NewParameter("propertiesWithHistory", names))
+	if err != nil {
+		return nil, err
+	}
+
+	return readEntity(objectToEntity(response, api.model))
+}
+
+// List - lists a page of objects
+// if no properties are specified all properties of the model are returned
+func (api *Objects) List(page *Page, props ...string) (*PageResponse, error) {
+	params := append(getPageParameters(page), api.getPropertyParameters(props)...)
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/objects/%s", api.objecttype), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := new(PageResponse)
+	readPaging(response, pr)
+
+	pr.Data, err = api.convertResults(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// BatchRead - reads multiple objects by id
+// big numbers of ids are read using multiple calls. Ids which don't exist are not contained in the result.
+func (api *Objects) BatchRead(ids []int64, props ...string) ([]interface{}, error) {
+	var entities []interface{}
+	properties := api.getPropertyNames(props)
+
+	for len(ids) > 0 {
+		count := len(ids)
+		if count > batchReadSize {
+			count = batchReadSize
+		}
+
+		inputs := make([]map[string]interface{}, count)
+		for index, id := range ids[:count] {
+			inputs[index] = map[string]interface{}{"id": cast.ToString(id)}
+		}
+		ids = ids[count:]
+
+		request := map[string]interface{}{
+			"properties": properties,
+			"inputs":     inputs}
+
+		response, err := api.rest.Post(fmt.Sprintf("crm/v3/objects/%s/batch/read", api.objecttype), request)
+		if err != nil {
+			return nil, err
+		}
+
+		batch, err := api.convertResults(response)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, batch...)
+	}
+
+	return entities, nil
+}
+
+// Delete - archives an object in hubspot
+func (api *Objects) Delete(id int64) error {
+	return api.rest.Delete(fmt.Sprintf("crm/v3/objects/%s/%d", api.objecttype, id))
+}
+
+// Merge - merges two objects of the same type
+// the object specified by mergeid is absorbed by the primary object
+func (api *Objects) Merge(primaryid int64, mergeid int64) (*MergeResult, error) {
+	request := map[string]interface{}{
+		"primaryObjectId": cast.ToString(primaryid),
+		"objectIdToMerge": cast.ToString(mergeid)}
+
+	response, err := api.rest.Post(fmt.Sprintf("crm/v3/objects/%s/merge", api.objecttype), request)
+	if err != nil {
+		return nil, err
+	}
+
+	// the objects are already merged, so the result is returned even if the entity can't be converted
+	entity, converr := objectToEntity(response, api.model)
+	result := &MergeResult{Entity: entity}
+
+	survivorid := cast.ToInt64(response["id"])
+	if survivorid == 0 {
+		survivorid = primaryid
+	}
+
+	// the merge response doesn't contain the merged ids. hubspot tracks all ids which were ever merged into
+	// an object, which includes ids previously merged into the absorbed object.
+	merged, err := api.rest.Get(fmt.Sprintf("crm/v3/objects/%s/%d", api.objecttype, survivorid),
+		NewParameter("properties", "hs_merged_object_ids"))
+	if err != nil {
+		result.MergedIDs = []int64{mergeid}
+		return result, err
+	}
+
+	properties, _ := merged["properties"].(map[string]interface{})
+	absorbed := false
+	for _, id := range strings.Split(cast.ToString(properties["hs_merged_object_ids"]), ";") {
+		if len(id) == 0 {
+			continue
+		}
+
+		mergedid := cast.ToInt64(id)
+		absorbed = absorbed || mergedid == mergeid
+		result.MergedIDs = append(result.MergedIDs, mergedid)
+	}
+
+	if !absorbed {
+		result.MergedIDs = append(result.MergedIDs, mergeid)
+	}
+
+	return result, converr
+}
+
+// Query - creates a query usable to search for objects
+func (api *Objects) Query() IQuery {
+	return &Query{
+		model: api.model,
+		url:   fmt.Sprintf("crm/v3/objects/%s/search", api.objecttype),
+		rest:  api.rest}
+}
@@ -0,0 +1,252 @@
+package hubspot
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseObjectMerge string = `{
+	"id": "512",
+	"properties": {
+	  "createdate": "2019-10-30T03:30:17.883Z",
+	  "hs_merged_object_ids": "83;1033",
+	  "name": "vertical GmbH",
+	  "website": "www.vertical.de"
+	},
+	"createdAt": "2019-10-30T03:30:17.883Z",
+	"updatedAt": "2019-12-07T16:50:06.678Z",
+	"archived": false
+  }`
+
+func TestObjectsInterfaceImpl(t *testing.T) {
+	var objects IObjects = &Objects{}
+
+	if objects != nil {
+		return
+	}
+}
+
+func TestObjectsCreate(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(Company{})))
+
+	created, err := api.Create(&Company{Name: "vertical GmbH", Website: "www.vertical.de"})
+	require.NoError(t, err)
+	require.Equal(t, "POST crm/v3/objects/companies?hapikey=xyz", rest.LastRequest())
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, "vertical GmbH", request["name"])
+	require.Equal(t, "www.vertical.de", request["website"])
+	require.NotContains(t, request, "id")
+
+	company := created.(*Company)
+	require.Equal(t, int64(512), company.ID)
+	require.Equal(t, "vertical GmbH", company.Name)
+}
+
+func TestObjectsUpdate(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(Company{})))
+
+	_, err := api.Update(512, &Company{VAT: "DE123"})
+	require.NoError(t, err)
+	require.Equal(t, "PATCH crm/v3/objects/companies/512?hapikey=xyz", rest.LastRequest())
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"umsatzsteuerid": "DE123"}, request)
+}
+
+func TestObjectsGet(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(Company{})))
+
+	_, err := api.Get(512, "name", "website")
+	require.NoError(t, err)
+	require.Equal(t, "GET crm/v3/objects/companies/512?hapikey=xyz&properties=name%2Cwebsite", rest.LastRequest())
+}
+
+func TestObjectsMerge(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(Company{})))
+
+	result, err := api.Merge(512, 1033)
+	require.NoError(t, err)
+	require.Equal(t, "POST crm/v3/objects/companies/merge?hapikey=xyz", rest.requests[0])
+	require.Equal(t, "GET crm/v3/objects/companies/512?hapikey=xyz&properties=hs_merged_object_ids", rest.LastRequest())
+
+	request := rest.bodies[0].(map[string]interface{})
+	require.Equal(t, "512", request["primaryObjectId"])
+	require.Equal(t, "1033", request["objectIdToMerge"])
+
+	require.Equal(t, []int64{83, 1033}, result.MergedIDs)
+	company := result.Entity.(*Company)
+	require.Equal(t, int64(512), company.ID)
+	require.Equal(t, "vertical GmbH", company.Name)
+}
+
+func TestObjectsMergeChained(t *testing.T) {
+	rest := &TestRest{Responses: []map[string]interface{}{
+		map[string]interface{}{
+			"id":         "512",
+			"properties": map[string]interface{}{"name": "vertical GmbH"}},
+		map[string]interface{}{
+			"id":         "512",
+			"properties": map[string]interface{}{"hs_merged_object_ids": "83;1040;1033"}}}}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(Company{})))
+
+	// 1040 was merged into 1033 before, so references to it have to be remapped as well
+	result, err := api.Merge(512, 1033)
+	require.NoError(t, err)
+	require.Equal(t, []int64{83, 1040, 1033}, result.MergedIDs)
+}
+
+type NullableCompany struct {
+	ID        int64 `hubspot:"id"`
+	Name      string
+	Employees *int `hubspot:"name=numberofemployees"`
+	Public    *bool
+	Revenue   sql.NullFloat64 `hubspot:"name=annualrevenue"`
+	Founded   sql.NullTime
+}
+
+func TestObjectsUpdatePointerFields(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(NullableCompany{})))
+
+	employees := 0
+	public := false
+	_, err := api.Update(512, &NullableCompany{Employees: &employees, Public: &public})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"numberofemployees": 0, "public": false}, request)
+}
+
+func TestObjectsUpdateClearNil(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(NullableCompany{}), Options{ClearNil: true}))
+
+	_, err := api.Update(512, &NullableCompany{Name: "vertical GmbH", Revenue: sql.NullFloat64{Float64: 0, Valid: true}})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{
+		"name":              "vertical GmbH",
+		"numberofemployees": "",
+		"public":            "",
+		"annualrevenue":     float64(0),
+		"founded":           ""}, request)
+}
+
+func TestObjectsOptionsKeepNil(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(NullableCompany{}), Options{Strict: true}))
+
+	_, err := api.Update(512, &NullableCompany{Name: "vertical GmbH"})
+	require.NoError(t, err)
+
+	// options which don't set ClearNil must not clear properties of nil fields
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH"}, request)
+}
+
+func TestObjectsIgnoreNil(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+	api := NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(NullableCompany{}), Options{IgnoreNil: true, ClearNil: true}))
+
+	_, err := api.Update(512, &NullableCompany{Name: "vertical GmbH"})
+	require.NoError(t, err)
+
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH"}, request)
+}
+
+func TestObjectsZeroValuePolicy(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseObjectMerge)}
+
+	api := NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(Company{}), Options{ZeroValues: ZeroValueSend}))
+	_, err := api.Update(512, &Company{Name: "vertical GmbH"})
+	require.NoError(t, err)
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH", "website": "", "umsatzsteuerid": ""}, request)
+
+	api = NewObjects(rest, "companies", MustNewModelWithOptions(reflect.TypeOf(Person{}), Options{ZeroValues: ZeroValueClear}))
+	_, err = api.Update(512, &Person{Name: "Peter"})
+	require.NoError(t, err)
+	request = rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"name": "Peter", "email": "", "humanage": ""}, request)
+}
+
+type StagedContact struct {
+	ID        int64     `hubspot:"id"`
+	Email     string    `hubspot:"name=email"`
+	Stage     string    `hubspot:"name=lifecyclestage,createonly"`
+	Score     int       `hubspot:"name=hubspotscore,calculated"`
+	Source    string    `hubspot:"name=hs_analytics_source,readonly"`
+	CreatedAt time.Time `hubspot:"name=createdate,readonly"`
+}
+
+func TestObjectsCreateOnlyFields(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id": "61574",
+		"properties": map[string]interface{}{
+			"email":               "max@example.com",
+			"lifecyclestage":      "lead",
+			"hubspotscore":        "12",
+			"hs_analytics_source": "DIRECT_TRAFFIC"}}}
+	api := NewObjects(rest, "contacts", MustNewModel(reflect.TypeOf(StagedContact{})))
+
+	contact := &StagedContact{Email: "max@example.com", Stage: "lead", Score: 12, Source: "DIRECT_TRAFFIC", CreatedAt: time.Now()}
+	result, err := api.Create(contact)
+	require.NoError(t, err)
+	request := rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"email": "max@example.com", "lifecyclestage": "lead"}, request)
+
+	created := result.(*StagedContact)
+	require.Equal(t, 12, created.Score)
+	require.Equal(t, "DIRECT_TRAFFIC", created.Source)
+
+	_, err = api.Update(61574, contact)
+	require.NoError(t, err)
+	request = rest.LastBody().(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"email": "max@example.com"}, request)
+
+	require.True(t, api.model.GetProperty("Score").Calculated)
+}
+
+func TestObjectsReadNullableFields(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(`{
+		"id": "512",
+		"properties": {
+		  "name": "vertical GmbH",
+		  "numberofemployees": "25",
+		  "public": "",
+		  "annualrevenue": "1000000.5",
+		  "founded": "1585735200000"
+		}
+	  }`)}
+	api := NewObjects(rest, "companies", MustNewModel(reflect.TypeOf(NullableCompany{})))
+
+	entity, err := api.Get(512)
+	require.NoError(t, err)
+
+	company := entity.(*NullableCompany)
+	require.NotNil(t, company.Employees)
+	require.Equal(t, 25, *company.Employees)
+	require.Nil(t, company.Public)
+	require.True(t, company.Revenue.Valid)
+	require.Equal(t, 1000000.5, company.Revenue.Float64)
+	require.True(t, company.Founded.Valid)
+	require.Equal(t, 2020, company.Founded.Time.Year())
+}
+
+func TestObjectsUpdateRequiresRestV3(t *testing.T) {
+	api := NewObjects(struct{ IRestClient }{&TestRest{}}, "companies", MustNewModel(reflect.TypeOf(Company{})))
+
+	_, err := api.Update(512, &Company{Name: "vertical GmbH"})
+	require.Error(t, err)
+}
@@ -1,5 +1,44 @@
 package hubspot
 
+import "time"
+
+// ZeroValuePolicy - determines how zero values of entity fields are sent to hubspot
+type ZeroValuePolicy int
+
+const (
+	// ZeroValueSkip - zero values are not sent (default)
+	ZeroValueSkip ZeroValuePolicy = iota
+	// ZeroValueSend - zero values are sent as they are (eg. 0, false, "")
+	ZeroValueSend
+	// ZeroValueClear - zero values are sent as empty string which clears the property in hubspot
+	ZeroValueClear
+)
+
+// Options - options of a model used when sending data to and reading data from hubspot
+//
+// pointer fields and nullable types (eg. sql.NullInt64) are always sent when they contain a value,
+// even if the value is a zero value. The zero value policy only applies to all other fields.
+//
+// values which can't be converted to the type of their field are skipped when reading entities.
+// Strict models return ConversionErrors instead of the entity in this case (see ConversionErrors).
+// Fields which can't be converted to property values always fail the request with a ConversionError.
+//
+// nil fields are not sent unless ClearNil is set. IgnoreNil takes precedence over ClearNil.
+//
+// the zero value of Options is the default used by NewModel, so options only need to set what differs.
 type Options struct {
-	IgnoreNil bool // ignores nil properties when updating data
+	IgnoreNil  bool            // ignores nil properties when updating data instead of clearing them
+	ClearNil   bool            // clears properties of nil fields by sending an empty string
+	ZeroValues ZeroValuePolicy // policy for fields containing zero values
+	Strict     bool            // returns an error if a value sent by hubspot can't be converted
+	Naming     NamingStrategy  // hubspot names of fields without a name in their tag (LowerCaseNaming if nil)
+	Location   *time.Location  // location times read from hubspot are converted to (UTC if nil, see AccountInfo.Location)
+}
+
+// getLocation - get location times read from hubspot are converted to
+func (options Options) getLocation() *time.Location {
+	if options.Location == nil {
+		return time.UTC
+	}
+	return options.Location
 }
@@ -0,0 +1,313 @@
+package hubspot
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// defaultOwnerProperty - hubspot property containing the owner id of crm objects
+const defaultOwnerProperty = "hubspot_owner_id"
+
+// Owner - owner of crm objects in hubspot
+type Owner struct {
+	ID        int64
+	UserID    int64
+	Email     string
+	FirstName string
+	LastName  string
+	Archived  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IOwners - interface for the hubspot owners api
+type IOwners interface {
+	List(page *Page, archived bool) (*PageResponse, error)
+	Get(id int64, archived bool) (*Owner, error)
+	GetByEmail(email string) (*Owner, error)
+}
+
+// Owners - access to owners api of hubspot using rest
+type Owners struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewOwners - creates a new owners api
+func NewOwners(rest IRestClient) *Owners {
+	return &Owners{rest: rest}
+}
+
+func readOwner(response map[string]interface{}) (*Owner, error) {
+	createdat, updatedat, err := readTimestamps(response, "createdAt", "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Owner{
+		ID:        cast.ToInt64(response["id"]),
+		UserID:    cast.ToInt64(response["userId"]),
+		Email:     cast.ToString(response["email"]),
+		FirstName: cast.ToString(response["firstName"]),
+		LastName:  cast.ToString(response["lastName"]),
+		Archived:  cast.ToBool(response["archived"]),
+		CreatedAt: createdat,
+		UpdatedAt: updatedat}, nil
+}
+
+func (api *Owners) listOwners(params []*Parameter) (*PageResponse, error) {
+	response, err := api.rest.Get("crm/v3/owners/", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := new(PageResponse)
+	readPaging(response, pr)
+
+	results, ok := response["results"].([]interface{})
+	if ok {
+		for _, obj := range results {
+			owner, ok := obj.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("Unexpected response structure from hubspot")
+			}
+
+			item, err := readOwner(owner)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, item)
+		}
+	}
+
+	return pr, nil
+}
+
+// List - lists a page of owners
+//
+// **Parameters**
+//   page    : page to list (nil to list the first page with default size)
+//   archived: true to list archived owners instead of active ones
+func (api *Owners) List(page *Page, archived bool) (*PageResponse, error) {
+	params := getPageParameters(page)
+	if archived {
+		params = append(params, NewParameter("archived", "true"))
+	}
+
+	return api.listOwners(params)
+}
+
+// Get - get an owner by id
+func (api *Owners) Get(id int64, archived bool) (*Owner, error) {
+	var params []*Parameter
+	if archived {
+		params = append(params, NewParameter("archived", "true"))
+	}
+
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/owners/%d", id), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return readOwner(response)
+}
+
+// GetByEmail - get an owner by its email address
+// returns nil if no owner with the specified email exists
+func (api *Owners) GetByEmail(email string) (*Owner, error) {
+	response, err := api.listOwners([]*Parameter{NewParameter("email", email)})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, nil
+	}
+
+	return response.Data[0].(*Owner), nil
+}
+
+// OwnerCache - in-memory cache of hubspot owners used to resolve owner ids
+type OwnerCache struct {
+	owners   IOwners          // api used to load owners
+	archived bool             // whether to load archived owners as well
+	byid     map[int64]*Owner // owners by their id
+	byemail  map[string]*Owner
+	mutex    sync.RWMutex
+}
+
+// NewOwnerCache - creates a new owner cache
+// call Refresh to fill the cache. Owners missing in the cache are loaded on demand.
+//
+// **Parameters**
+//   owners  : api used to load owners
+//   archived: true to load archived owners on refresh as well
+func NewOwnerCache(owners IOwners, archived bool) *OwnerCache {
+	return &OwnerCache{
+		owners:   owners,
+		archived: archived,
+		byid:     make(map[int64]*Owner),
+		byemail:  make(map[string]*Owner)}
+}
+
+func (cache *OwnerCache) loadPages(archived bool, byid map[int64]*Owner, byemail map[string]*Owner) error {
+	var page *Page
+	for {
+		response, err := cache.owners.List(page, archived)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range response.Data {
+			owner := item.(*Owner)
+			byid[owner.ID] = owner
+			if len(owner.Email) > 0 {
+				byemail[strings.ToLower(owner.Email)] = owner
+			}
+		}
+
+		if !response.HasMore {
+			return nil
+		}
+		page = NewPage(response.Offset, 0)
+	}
+}
+
+// Refresh - reloads all owners from hubspot
+func (cache *OwnerCache) Refresh() error {
+	byid := make(map[int64]*Owner)
+	byemail := make(map[string]*Owner)
+
+	if cache.archived {
+		// load archived owners first so active owners win on duplicate emails
+		err := cache.loadPages(true, byid, byemail)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := cache.loadPages(false, byid, byemail)
+	if err != nil {
+		return err
+	}
+
+	cache.mutex.Lock()
+	cache.byid = byid
+	cache.byemail = byemail
+	cache.mutex.Unlock()
+	return nil
+}
+
+func (cache *OwnerCache) add(owner *Owner) {
+	cache.mutex.Lock()
+	cache.byid[owner.ID] = owner
+	if len(owner.Email) > 0 {
+		cache.byemail[strings.ToLower(owner.Email)] = owner
+	}
+	cache.mutex.Unlock()
+}
+
+// Get - get an owner by id
+// owners not contained in the cache are loaded from hubspot
+func (cache *OwnerCache) Get(id int64) (*Owner, error) {
+	cache.mutex.RLock()
+	owner, ok := cache.byid[id]
+	cache.mutex.RUnlock()
+	if ok {
+		return owner, nil
+	}
+
+	owner, err := cache.owners.Get(id, cache.archived)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.add(owner)
+	return owner, nil
+}
+
+// GetByEmail - get an owner by email
+// owners not contained in the cache are loaded from hubspot. Returns nil if no owner exists with the specified email
+func (cache *OwnerCache) GetByEmail(email string) (*Owner, error) {
+	cache.mutex.RLock()
+	owner, ok := cache.byemail[strings.ToLower(email)]
+	cache.mutex.RUnlock()
+	if ok {
+		return owner, nil
+	}
+
+	owner, err := cache.owners.GetByEmail(email)
+	if err != nil || owner == nil {
+		return nil, err
+	}
+
+	cache.add(owner)
+	return owner, nil
+}
+
+// Email - get email of an owner by id
+func (cache *OwnerCache) Email(id int64) (string, error) {
+	owner, err := cache.Get(id)
+	if err != nil {
+		return "", err
+	}
+
+	return owner.Email, nil
+}
+
+// Resolve - fills owner email fields of an entity based on the owner ids contained in the entity
+// the entity has to be a pointer to a struct of the model. Owner email fields are specified using the
+// 'owneremail' tag (see NewModel)
+func (cache *OwnerCache) Resolve(model *Model, entity interface{}) error {
+	if len(model.owneremails) == 0 {
+		return nil
+	}
+
+	refvalue := reflect.ValueOf(entity)
+	if refvalue.Kind() != reflect.Ptr {
+		return errors.Errorf("Entity has to be a pointer to be able to resolve owners")
+	}
+	refvalue = refvalue.Elem()
+
+	for _, emailprop := range model.owneremails {
+		idprop := model.getPropertyByHubspotName(emailprop.OwnerProperty)
+		if idprop == nil {
+			return errors.Errorf("Model doesn't contain owner property '%s'", emailprop.OwnerProperty)
+		}
+
+		ownerid := cast.ToInt64(idprop.GetValue(refvalue))
+		if ownerid == 0 {
+			continue
+		}
+
+		email, err := cache.Email(ownerid)
+		if err != nil {
+			return err
+		}
+
+		field := emailprop.field(refvalue, true)
+		if field.IsValid() {
+			field.SetString(email)
+		}
+	}
+
+	return nil
+}
+
+// ResolveAll - resolves owner email fields of multiple entities (see Resolve)
+func (cache *OwnerCache) ResolveAll(model *Model, entities []interface{}) error {
+	for _, entity := range entities {
+		err := cache.Resolve(model, entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseOwnerList string = `{
+	"results": [
+	  {
+		"id": "41629779",
+		"email": "peter@vertical.de",
+		"firstName": "Peter",
+		"lastName": "Lack",
+		"userId": 9586504,
+		"createdAt": "2019-12-25T13:01:35.228Z",
+		"updatedAt": "2020-03-27T15:02:35.228Z",
+		"archived": false
+	  },
+	  {
+		"id": "41629780",
+		"email": "monika@vertical.de",
+		"firstName": "Monika",
+		"lastName": "Left",
+		"userId": 9586505,
+		"createdAt": "2019-12-25T13:01:35.228Z",
+		"updatedAt": "2019-12-25T13:01:35.228Z",
+		"archived": false
+	  }
+	],
+	"paging": {
+	  "next": {
+		"after": "2",
+		"link": "?after=2"
+	  }
+	}
+  }`
+
+type OwnedDeal struct {
+	ID         int64  `hubspot:"id"`
+	Name       string `hubspot:"name=dealname"`
+	Owner      int64  `hubspot:"name=hubspot_owner_id"`
+	OwnerEmail string `hubspot:"owneremail"`
+}
+
+func TestOwnersInterfaceImpl(t *testing.T) {
+	var owners IOwners = &Owners{}
+
+	if owners != nil {
+		return
+	}
+}
+
+func TestOwnersList(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseOwnerList)}
+	api := NewOwners(rest)
+
+	response, err := api.List(NewPage(5, 20), true)
+	require.NoError(t, err)
+	require.Equal(t, "GET crm/v3/owners/?hapikey=xyz&limit=20&after=5&archived=true", rest.LastRequest())
+
+	require.True(t, response.HasMore)
+	require.Equal(t, int64(2), response.Offset)
+	require.Equal(t, 2, len(response.Data))
+
+	owner := response.Data[0].(*Owner)
+	require.Equal(t, int64(41629779), owner.ID)
+	require.Equal(t, int64(9586504), owner.UserID)
+	require.Equal(t, "peter@vertical.de", owner.Email)
+	require.Equal(t, "Peter", owner.FirstName)
+	require.Equal(t, "Lack", owner.LastName)
+	require.Equal(t, 2019, owner.CreatedAt.Year())
+	require.Equal(t, 2020, owner.UpdatedAt.Year())
+}
+
+func TestOwnersGetByEmail(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseOwnerList)}
+	api := NewOwners(rest)
+
+	owner, err := api.GetByEmail("peter@vertical.de")
+	require.NoError(t, err)
+	require.Equal(t, "GET crm/v3/owners/?hapikey=xyz&email=peter%40vertical.de", rest.LastRequest())
+	require.Equal(t, int64(41629779), owner.ID)
+}
+
+func TestOwnersGetByEmailNotFound(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"results": []interface{}{}}}
+	api := NewOwners(rest)
+
+	owner, err := api.GetByEmail("nobody@vertical.de")
+	require.NoError(t, err)
+	require.Nil(t, owner)
+}
+
+func TestOwnerCacheResolve(t *testing.T) {
+	rest := &TestRest{
+		Responses: []map[string]interface{}{
+			readTestResponse(responseOwnerList),
+			map[string]interface{}{"results": []interface{}{}}}}
+	cache := NewOwnerCache(NewOwners(rest), false)
+	require.NoError(t, cache.Refresh())
+	require.Equal(t, "GET crm/v3/owners/?hapikey=xyz&after=2", rest.LastRequest())
+
+	model := MustNewModel(reflect.TypeOf(OwnedDeal{}))
+	deal := &OwnedDeal{Name: "Deal", Owner: 41629780}
+	require.NoError(t, cache.Resolve(model, deal))
+	require.Equal(t, "monika@vertical.de", deal.OwnerEmail)
+
+	properties, err := getProperties(deal, "name", model, writeCreate)
+	require.NoError(t, err)
+	for _, property := range properties {
+		require.NotEqual(t, "owneremail", property["name"])
+	}
+}
+
+func TestOwnerEmailTagExactMatch(t *testing.T) {
+	type MistypedDeal struct {
+		OwnerEmail string `hubspot:"owneremails"`
+	}
+
+	model := MustNewModel(reflect.TypeOf(MistypedDeal{}))
+	require.Empty(t, model.owneremails)
+	require.NotNil(t, model.GetProperty("OwnerEmail"))
+}
+
+func TestOwnerCacheLoadsMissingOwner(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"id":    "77",
+		"email": "new@vertical.de"}}
+	cache := NewOwnerCache(NewOwners(rest), true)
+
+	email, err := cache.Email(77)
+	require.NoError(t, err)
+	require.Equal(t, "new@vertical.de", email)
+	require.Equal(t, "GET crm/v3/owners/77?hapikey=xyz&archived=true", rest.LastRequest())
+
+	rest.Response = nil
+	owner, err := cache.GetByEmail("NEW@vertical.de")
+	require.NoError(t, err)
+	require.Equal(t, int64(77), owner.ID)
+	require.Equal(t, 1, len(rest.requests))
+}
@@ -1,5 +1,11 @@
 package hubspot
 
+import (
+	"fmt"
+
+	"github.com/spf13/cast"
+)
+
 // Page - parameters for a page to be listed
 type Page struct {
 	Offset int64
@@ -53,3 +59,33 @@ func FindInPages(pager func(*Page) (*PageResponse, error), predicate func(data i
 
 	return nil, nil
 }
+
+// getPageParameters - get url parameters for a page of a v3 list endpoint
+func getPageParameters(page *Page) []*Parameter {
+	var parameters []*Parameter
+	if page != nil {
+		if page.Count > 0 {
+			parameters = append(parameters, NewParameter("limit", fmt.Sprintf("%d", page.Count)))
+		}
+
+		if page.Offset > 0 {
+			parameters = append(parameters, NewParameter("after", fmt.Sprintf("%d", page.Offset)))
+		}
+	}
+
+	return parameters
+}
+
+// readPaging - reads paging information of a v3 list response
+func readPaging(response map[string]interface{}, pr *PageResponse) {
+	paging, ok := response["paging"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	next, ok := paging["next"].(map[string]interface{})
+	if ok {
+		pr.HasMore = true
+		pr.Offset = cast.ToInt64(next["after"])
+	}
+}
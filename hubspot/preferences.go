@@ -0,0 +1,168 @@
+package hubspot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// LegalBasis - legal basis to communicate with a contact
+type LegalBasis string
+
+const (
+	LegalBasisLegitimateInterestPQL    LegalBasis = "LEGITIMATE_INTEREST_PQL"
+	LegalBasisLegitimateInterestClient LegalBasis = "LEGITIMATE_INTEREST_CLIENT"
+	LegalBasisLegitimateInterestOther  LegalBasis = "LEGITIMATE_INTEREST_OTHER"
+	LegalBasisPerformanceOfContract    LegalBasis = "PERFORMANCE_OF_CONTRACT"
+	LegalBasisConsentWithNotice        LegalBasis = "CONSENT_WITH_NOTICE"
+	LegalBasisNonGDPR                  LegalBasis = "NON_GDPR"
+	LegalBasisProcessAndStore          LegalBasis = "PROCESS_AND_STORE"
+)
+
+// SubscriptionDefinition - type of email subscription contacts can subscribe to
+type SubscriptionDefinition struct {
+	ID                  int64
+	Name                string
+	Description         string
+	Purpose             string
+	CommunicationMethod string
+	Active              bool
+	Default             bool
+	Internal            bool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// SubscriptionStatus - status of an email address for a subscription type
+type SubscriptionStatus struct {
+	ID                    int64 // id of subscription definition
+	Name                  string
+	Subscribed            bool
+	SourceOfStatus        string // eg. SUBSCRIPTION_STATUS, PORTAL_WIDE_STATUS
+	LegalBasis            LegalBasis
+	LegalBasisExplanation string
+}
+
+// ICommunicationPreferences - interface for the hubspot communication preferences api
+type ICommunicationPreferences interface {
+	Definitions() ([]*SubscriptionDefinition, error)
+	Status(email string) ([]*SubscriptionStatus, error)
+	Subscribe(email string, subscriptionid int64, legalbasis LegalBasis, explanation string) (*SubscriptionStatus, error)
+	Unsubscribe(email string, subscriptionid int64, legalbasis LegalBasis, explanation string) (*SubscriptionStatus, error)
+	UnsubscribeAll(email string) error
+}
+
+// CommunicationPreferences - access to email subscription preferences of contacts using rest
+type CommunicationPreferences struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewCommunicationPreferences - creates a new communication preferences api
+func NewCommunicationPreferences(rest IRestClient) *CommunicationPreferences {
+	return &CommunicationPreferences{rest: rest}
+}
+
+func readSubscriptionStatus(data map[string]interface{}) *SubscriptionStatus {
+	return &SubscriptionStatus{
+		ID:                    cast.ToInt64(data["id"]),
+		Name:                  cast.ToString(data["name"]),
+		Subscribed:            cast.ToString(data["status"]) == "SUBSCRIBED",
+		SourceOfStatus:        cast.ToString(data["sourceOfStatus"]),
+		LegalBasis:            LegalBasis(cast.ToString(data["legalBasis"])),
+		LegalBasisExplanation: cast.ToString(data["legalBasisExplanation"])}
+}
+
+// Definitions - lists all subscription types of the portal
+func (api *CommunicationPreferences) Definitions() ([]*SubscriptionDefinition, error) {
+	response, err := api.rest.Get("communication-preferences/v3/definitions")
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []*SubscriptionDefinition
+	results, _ := response["subscriptionDefinitions"].([]interface{})
+	for _, obj := range results {
+		definition, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		createdat, updatedat, err := readTimestamps(definition, "createdAt", "updatedAt")
+		if err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, &SubscriptionDefinition{
+			ID:                  cast.ToInt64(definition["id"]),
+			Name:                cast.ToString(definition["name"]),
+			Description:         cast.ToString(definition["description"]),
+			Purpose:             cast.ToString(definition["purpose"]),
+			CommunicationMethod: cast.ToString(definition["communicationMethod"]),
+			Active:              cast.ToBool(definition["isActive"]),
+			Default:             cast.ToBool(definition["isDefault"]),
+			Internal:            cast.ToBool(definition["isInternal"]),
+			CreatedAt:           createdat,
+			UpdatedAt:           updatedat})
+	}
+
+	return definitions, nil
+}
+
+// Status - get subscription statuses of an email address
+func (api *CommunicationPreferences) Status(email string) ([]*SubscriptionStatus, error) {
+	response, err := api.rest.Get(fmt.Sprintf("communication-preferences/v3/status/email/%s", email))
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []*SubscriptionStatus
+	results, _ := response["subscriptionStatuses"].([]interface{})
+	for _, obj := range results {
+		status, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		statuses = append(statuses, readSubscriptionStatus(status))
+	}
+
+	return statuses, nil
+}
+
+func (api *CommunicationPreferences) changeSubscription(address string, email string, subscriptionid int64, legalbasis LegalBasis, explanation string) (*SubscriptionStatus, error) {
+	request := map[string]interface{}{
+		"emailAddress":   email,
+		"subscriptionId": cast.ToString(subscriptionid)}
+
+	if len(legalbasis) > 0 {
+		request["legalBasis"] = legalbasis
+		request["legalBasisExplanation"] = explanation
+	}
+
+	response, err := api.rest.Post(address, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return readSubscriptionStatus(response), nil
+}
+
+// Subscribe - subscribes an email address to a subscription type
+// legal basis and explanation are only required for portals with GDPR features enabled
+func (api *CommunicationPreferences) Subscribe(email string, subscriptionid int64, legalbasis LegalBasis, explanation string) (*SubscriptionStatus, error) {
+	return api.changeSubscription("communication-preferences/v3/subscribe", email, subscriptionid, legalbasis, explanation)
+}
+
+// Unsubscribe - unsubscribes an email address from a subscription type
+func (api *CommunicationPreferences) Unsubscribe(email string, subscriptionid int64, legalbasis LegalBasis, explanation string) (*SubscriptionStatus, error) {
+	return api.changeSubscription("communication-preferences/v3/unsubscribe", email, subscriptionid, legalbasis, explanation)
+}
+
+// UnsubscribeAll - opts an email address out of all email communication
+func (api *CommunicationPreferences) UnsubscribeAll(email string) error {
+	_, err := api.rest.Put(fmt.Sprintf("email/public/v1/subscriptions/%s", email), map[string]interface{}{
+		"unsubscribeFromAll": true})
+	return err
+}
@@ -0,0 +1,100 @@
+package hubspot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseSubscriptionStatus string = `{
+	"recipient": "peter@lack.de",
+	"subscriptionStatuses": [
+	  {
+		"id": "7",
+		"name": "Newsletter",
+		"description": "Monthly newsletter",
+		"status": "SUBSCRIBED",
+		"sourceOfStatus": "SUBSCRIPTION_STATUS",
+		"legalBasis": "CONSENT_WITH_NOTICE",
+		"legalBasisExplanation": "Signed up on website"
+	  },
+	  {
+		"id": "8",
+		"name": "Product updates",
+		"status": "NOT_SUBSCRIBED",
+		"sourceOfStatus": "PORTAL_WIDE_STATUS"
+	  }
+	]
+  }`
+
+func TestPreferencesInterfaceImpl(t *testing.T) {
+	var preferences ICommunicationPreferences = &CommunicationPreferences{}
+
+	if preferences != nil {
+		return
+	}
+}
+
+func TestPreferencesDefinitions(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"subscriptionDefinitions": []interface{}{
+			map[string]interface{}{
+				"id":        "7",
+				"name":      "Newsletter",
+				"isActive":  true,
+				"createdAt": "2019-01-01T10:00:00Z"}}}}
+	api := NewCommunicationPreferences(rest)
+
+	definitions, err := api.Definitions()
+	require.NoError(t, err)
+	require.Equal(t, "GET communication-preferences/v3/definitions?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, 1, len(definitions))
+	require.Equal(t, int64(7), definitions[0].ID)
+	require.True(t, definitions[0].Active)
+	require.Equal(t, 2019, definitions[0].CreatedAt.Year())
+}
+
+func TestPreferencesStatus(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseSubscriptionStatus)}
+	api := NewCommunicationPreferences(rest)
+
+	statuses, err := api.Status("peter@lack.de")
+	require.NoError(t, err)
+	require.Equal(t, "GET communication-preferences/v3/status/email/peter@lack.de?hapikey=xyz", rest.LastRequest())
+
+	require.Equal(t, 2, len(statuses))
+	require.True(t, statuses[0].Subscribed)
+	require.Equal(t, LegalBasisConsentWithNotice, statuses[0].LegalBasis)
+	require.False(t, statuses[1].Subscribed)
+	require.Equal(t, "PORTAL_WIDE_STATUS", statuses[1].SourceOfStatus)
+}
+
+func TestPreferencesSubscribe(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "7", "status": "SUBSCRIBED"}}
+	api := NewCommunicationPreferences(rest)
+
+	status, err := api.Subscribe("peter@lack.de", 7, LegalBasisConsentWithNotice, "Signed up on website")
+	require.NoError(t, err)
+	require.Equal(t, "POST communication-preferences/v3/subscribe?hapikey=xyz", rest.LastRequest())
+	require.True(t, status.Subscribed)
+	require.Equal(t, map[string]interface{}{
+		"emailAddress":          "peter@lack.de",
+		"subscriptionId":        "7",
+		"legalBasis":            LegalBasisConsentWithNotice,
+		"legalBasisExplanation": "Signed up on website"}, rest.LastBody())
+
+	_, err = api.Unsubscribe("peter@lack.de", 7, "", "")
+	require.NoError(t, err)
+	require.Equal(t, "POST communication-preferences/v3/unsubscribe?hapikey=xyz", rest.LastRequest())
+	require.NotContains(t, rest.LastBody(), "legalBasis")
+}
+
+func TestPreferencesUnsubscribeAll(t *testing.T) {
+	rest := &TestRest{}
+	api := NewCommunicationPreferences(rest)
+
+	err := api.UnsubscribeAll("peter@lack.de")
+	require.NoError(t, err)
+	require.Equal(t, "PUT email/public/v1/subscriptions/peter@lack.de?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, map[string]interface{}{"unsubscribeFromAll": true}, rest.LastBody())
+}
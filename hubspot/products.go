@@ -0,0 +1,26 @@
+package hubspot
+
+// Product - product of the hubspot product library
+// can be used as entity type of a model for the products api
+type Product struct {
+	ID          int64   `hubspot:"id"`
+	Name        string  `hubspot:"name=name"`
+	Description string  `hubspot:"name=description"`
+	SKU         string  `hubspot:"name=hs_sku"`
+	Price       float64 `hubspot:"name=price"`
+}
+
+// IProducts - interface for the hubspot products api
+type IProducts interface {
+	IObjects
+}
+
+// Products - access to the product library of hubspot using rest
+type Products struct {
+	*Objects
+}
+
+// NewProducts - creates a new products api
+func NewProducts(rest IRestClient, model *Model) *Products {
+	return &Products{Objects: NewObjects(rest, "products", model)}
+}
@@ -1,8 +1,6 @@
 package hubspot
 
 import (
-	"reflect"
-
 	"github.com/pkg/errors"
 	"github.com/spf13/cast"
 )
@@ -61,24 +59,8 @@ type Query struct {
 	sorts      []*Sort        // sort criterias
 }
 
-func (q *Query) toEntity(response map[string]interface{}) interface{} {
-	entity := reflect.New(q.model.datatype)
-	entity = entity.Elem()
-
-	if q.model.id != nil {
-		q.model.id.SetValue(response, "id", entity)
-	}
-
-	properties, ok := response["properties"].(map[string]interface{})
-	if !ok {
-		return entity.Addr().Interface()
-	}
-
-	for _, prop := range q.model.properties {
-		prop.SetValue(properties, prop.HubspotName, entity)
-	}
-
-	return entity.Addr().Interface()
+func (q *Query) toEntity(response map[string]interface{}) (interface{}, error) {
+	return readEntity(objectToEntity(response, q.model))
 }
 
 // Where - specifies a filter to query for
@@ -161,15 +143,7 @@ func (q *Query) Execute(page *Page) (*PageResponse, error) {
 	}
 
 	pr := new(PageResponse)
-
-	paging, ok := response["paging"].(map[string]interface{})
-	if ok {
-		nextrp, ok := paging["next"].(map[string]interface{})
-		if ok {
-			pr.HasMore = true
-			pr.Offset = cast.ToInt64(nextrp["after"])
-		}
-	}
+	readPaging(response, pr)
 
 	results, ok := response["results"].([]interface{})
 	if ok {
@@ -179,7 +153,12 @@ func (q *Query) Execute(page *Page) (*PageResponse, error) {
 				return nil, errors.Errorf("Unexpected response structure from hubspot")
 			}
 
-			pr.Data = append(pr.Data, q.toEntity(objdata))
+			entity, err := q.toEntity(objdata)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Data = append(pr.Data, entity)
 		}
 	}
 
@@ -0,0 +1,78 @@
+package hubspot
+
+import "time"
+
+// Quote - quote sent to a customer for a deal
+// can be used as entity type of a model for the quotes api
+type Quote struct {
+	ID             int64     `hubspot:"id"`
+	Title          string    `hubspot:"name=hs_title"`
+	ExpirationDate time.Time `hubspot:"name=hs_expiration_date"`
+	Status         string    `hubspot:"name=hs_status"`
+	Amount         float64   `hubspot:"name=hs_quote_amount,noexport"`
+}
+
+// IQuotes - interface for the hubspot quotes api
+type IQuotes interface {
+	IObjects
+	CreateForDeal(dealid int64, quote interface{}) (interface{}, error)
+	ListForDeal(dealid int64, props ...string) ([]interface{}, error)
+	AddLineItems(quoteid int64, lineitemids ...int64) error
+}
+
+// Quotes - access to quotes of hubspot using rest
+type Quotes struct {
+	*Objects
+	associations *Associations // api used to link quotes to deals and line items
+}
+
+// NewQuotes - creates a new quotes api
+func NewQuotes(rest IRestClient, model *Model) *Quotes {
+	return &Quotes{
+		Objects:      NewObjects(rest, "quotes", model),
+		associations: NewAssociations(rest)}
+}
+
+// CreateForDeal - creates a quote and associates it to a deal
+func (api *Quotes) CreateForDeal(dealid int64, quote interface{}) (interface{}, error) {
+	// entities which can't be converted are associated anyway since the quote already exists
+	entity, id, converr := api.create(quote)
+	if _, ok := converr.(ConversionErrors); converr != nil && !ok {
+		return nil, converr
+	}
+
+	err := api.associations.Create(id, dealid, AssociationQuoteToDeal)
+	if err != nil {
+		// the quote already exists, so it is returned to allow the caller to retry or clean up
+		return entity, err
+	}
+
+	return entity, converr
+}
+
+// ListForDeal - lists all quotes associated to a deal
+func (api *Quotes) ListForDeal(dealid int64, props ...string) ([]interface{}, error) {
+	ids, err := api.associations.ListAll(dealid, AssociationDealToQuote)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.BatchRead(ids, props...)
+}
+
+// AddLineItems - associates line items to a quote
+func (api *Quotes) AddLineItems(quoteid int64, lineitemids ...int64) error {
+	if len(lineitemids) == 0 {
+		return nil
+	}
+
+	associations := make([]*Association, len(lineitemids))
+	for index, id := range lineitemids {
+		associations[index] = &Association{
+			From: quoteid,
+			To:   id,
+			Type: AssociationQuoteToLineItem}
+	}
+
+	return api.associations.CreateBulk(associations)
+}
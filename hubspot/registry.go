@@ -0,0 +1,81 @@
+package hubspot
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ModelRegistry - caches models per entity type
+// models are created on first use and shared afterwards. The registry is safe for concurrent use.
+type ModelRegistry struct {
+	options Options                 // options used to create models
+	models  map[reflect.Type]*Model // models by entity type
+	mutex   sync.RWMutex
+}
+
+// NewModelRegistry - creates a new model registry
+//
+// **Parameters**
+//   options: options used to create models (see NewModelWithOptions)
+func NewModelRegistry(options Options) *ModelRegistry {
+	return &ModelRegistry{
+		options: options,
+		models:  make(map[reflect.Type]*Model)}
+}
+
+var defaultRegistry = NewModelRegistry(Options{})
+
+// GetModel - get the model of an entity type from the default registry
+// models of the default registry are created using the default options of NewModel
+func GetModel(entitytype reflect.Type) (*Model, error) {
+	return defaultRegistry.Get(entitytype)
+}
+
+// Get - get the model of an entity type
+// creates the model if the type is requested for the first time. Pointer types are resolved to their struct type.
+func (registry *ModelRegistry) Get(entitytype reflect.Type) (*Model, error) {
+	entitytype = derefType(entitytype)
+
+	registry.mutex.RLock()
+	model, ok := registry.models[entitytype]
+	registry.mutex.RUnlock()
+	if ok {
+		return model, nil
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	// model could have been created while waiting for the lock
+	model, ok = registry.models[entitytype]
+	if ok {
+		return model, nil
+	}
+
+	model, err := NewModelWithOptions(entitytype, registry.options)
+	if err != nil {
+		return nil, err
+	}
+
+	registry.models[entitytype] = model
+	return model, nil
+}
+
+// MustGet - get the model of an entity type like Get but panics if the model is invalid
+func (registry *ModelRegistry) MustGet(entitytype reflect.Type) *Model {
+	model, err := registry.Get(entitytype)
+	if err != nil {
+		panic(err)
+	}
+
+	return model
+}
+
+// Register - registers a model for its entity type
+// used to provide models created with custom options. Replaces a model already registered for the type.
+func (registry *ModelRegistry) Register(model *Model) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.models[model.datatype] = model
+}
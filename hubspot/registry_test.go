@@ -0,0 +1,65 @@
+package hubspot
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryCachesModels(t *testing.T) {
+	registry := NewModelRegistry(Options{Naming: SnakeCaseNaming})
+
+	model, err := registry.Get(reflect.TypeOf(NamedContact{}))
+	require.NoError(t, err)
+	require.Equal(t, "first_name", model.GetProperty("FirstName").HubspotName)
+
+	pointermodel, err := registry.Get(reflect.TypeOf(&NamedContact{}))
+	require.NoError(t, err)
+	require.True(t, model == pointermodel)
+}
+
+func TestRegistryConcurrentGet(t *testing.T) {
+	registry := NewModelRegistry(Options{})
+
+	models := make([]*Model, 16)
+	var wait sync.WaitGroup
+	for i := range models {
+		wait.Add(1)
+		go func(index int) {
+			defer wait.Done()
+			models[index] = registry.MustGet(reflect.TypeOf(Company{}))
+		}(i)
+	}
+	wait.Wait()
+
+	for _, model := range models {
+		require.True(t, models[0] == model)
+	}
+}
+
+func TestRegistryInvalidModel(t *testing.T) {
+	type InvalidDeal struct {
+		Companies []string `hubspot:"companies"`
+	}
+
+	registry := NewModelRegistry(Options{})
+	_, err := registry.Get(reflect.TypeOf(InvalidDeal{}))
+	require.Error(t, err)
+	require.Panics(t, func() { registry.MustGet(reflect.TypeOf(InvalidDeal{})) })
+}
+
+func TestRegistryRegister(t *testing.T) {
+	registry := NewModelRegistry(Options{})
+	model := MustNewModelWithOptions(reflect.TypeOf(Company{}), Options{ZeroValues: ZeroValueClear})
+	registry.Register(model)
+
+	registered, err := registry.Get(reflect.TypeOf(Company{}))
+	require.NoError(t, err)
+	require.True(t, model == registered)
+
+	defaultmodel, err := GetModel(reflect.TypeOf(Company{}))
+	require.NoError(t, err)
+	require.True(t, model != defaultmodel)
+}
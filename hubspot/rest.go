@@ -3,8 +3,13 @@ package hubspot
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,12 +21,29 @@ var httpclient http.Client
 
 const defaultquota = time.Duration(float64(time.Second) * 1.05)
 
+// key containing the items of responses which consist of a plain json array
+const arrayResponseKey = "results"
+
 // Parameter - parameter for a rest client
 type Parameter struct {
 	Key   string
 	Value string
 }
 
+// MultipartFile - file sent in a multipart request
+type MultipartFile struct {
+	Field       string    // name of form field containing the file
+	Name        string    // name of the file
+	ContentType string    // content type of the file (application/octet-stream if not specified)
+	Content     io.Reader // content of the file
+}
+
+// MultipartForm - form data sent in a multipart request
+type MultipartForm struct {
+	Fields map[string]string
+	Files  []*MultipartFile
+}
+
 // IRestClient - interface for a client sending rest requests to hubspot
 type IRestClient interface {
 	Post(url string, request interface{}, params ...*Parameter) (map[string]interface{}, error)
@@ -32,6 +54,41 @@ type IRestClient interface {
 	EndQuota()
 }
 
+// IRestClientV3 - interface for a rest client additionally supporting requests used by crm v3 apis
+// apis which need these requests fail if their rest client doesn't implement this interface
+type IRestClientV3 interface {
+	IRestClient
+	Patch(url string, request interface{}, params ...*Parameter) (map[string]interface{}, error)
+	PostMultipart(url string, form *MultipartForm, params ...*Parameter) (map[string]interface{}, error)
+	Download(url string) (io.ReadCloser, error)
+}
+
+// getRestV3 - get a rest client supporting the requests of crm v3 apis
+func getRestV3(rest IRestClient) (IRestClientV3, error) {
+	client, ok := rest.(IRestClientV3)
+	if !ok {
+		return nil, errors.Errorf("Rest client doesn't implement IRestClientV3")
+	}
+
+	return client, nil
+}
+
+// RestError - error response received from hubspot
+type RestError struct {
+	StatusCode int    // http status code of response
+	Status     string // http status text of response
+	Body       string // body of response containing error details
+}
+
+// Error - get error message
+func (err *RestError) Error() string {
+	if len(err.Body) == 0 {
+		return fmt.Sprintf("%d: %s", err.StatusCode, err.Status)
+	}
+
+	return err.Body
+}
+
 // RestClient - client used to send rest requests to hubspot
 type RestClient struct {
 	apikey    string
@@ -58,31 +115,44 @@ func NewRest(address string, apikey string) *RestClient {
 
 func (client *RestClient) buildBaseURL(address string, params ...*Parameter) *strings.Builder {
 	var builder strings.Builder
-	builder.WriteString(client.address)
-	builder.WriteString(address)
-	builder.WriteString("?hapikey=")
-	builder.WriteString(client.apikey)
-
-	if len(params) > 0 {
-		for _, param := range params {
-			builder.WriteRune('&')
-			builder.WriteString(param.Key)
-			builder.WriteRune('=')
-			builder.WriteString(url.QueryEscape(param.Value))
+	separator := '?'
+	// some apis are served by other hosts than the default api host, the api key is only sent to the api host
+	if strings.HasPrefix(address, "https://") {
+		builder.WriteString(address)
+		if strings.ContainsRune(address, '?') {
+			separator = '&'
 		}
+	} else {
+		builder.WriteString(client.address)
+		builder.WriteString(address)
+		builder.WriteString("?hapikey=")
+		builder.WriteString(client.apikey)
+		separator = '&'
+	}
+
+	for _, param := range params {
+		builder.WriteRune(separator)
+		builder.WriteString(param.Key)
+		builder.WriteRune('=')
+		builder.WriteString(url.QueryEscape(param.Value))
+		separator = '&'
 	}
 	return &builder
 }
 
 func (client *RestClient) checkError(response *http.Response) error {
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		if response.ContentLength <= 0 {
-			return errors.Errorf("%d: %s", response.StatusCode, response.Status)
+		resterr := &RestError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status}
+
+		if response.ContentLength != 0 {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(response.Body)
+			resterr.Body = buf.String()
 		}
 
-		buf := new(bytes.Buffer)
-		buf.ReadFrom(response.Body)
-		return errors.Errorf("%s", buf.String())
+		return resterr
 	}
 
 	return nil
@@ -94,14 +164,22 @@ func (client *RestClient) readResponse(response *http.Response) (map[string]inte
 	}
 
 	if response.ContentLength != 0 {
-		jresponse := make(map[string]interface{})
+		var jresponse interface{}
 		decoder := json.NewDecoder(response.Body)
 		err := decoder.Decode(&jresponse)
 		if err != nil {
 			return nil, err
 		}
 
-		return jresponse, nil
+		switch data := jresponse.(type) {
+		case map[string]interface{}:
+			return data, nil
+		case []interface{}:
+			// some older endpoints respond with plain arrays
+			return map[string]interface{}{arrayResponseKey: data}, nil
+		}
+
+		return nil, errors.Errorf("Unexpected response structure from hubspot")
 	}
 
 	return nil, errors.New("No response body")
@@ -124,6 +202,23 @@ func (client *RestClient) Get(address string, params ...*Parameter) (map[string]
 	return client.readResponse(response)
 }
 
+// Download - opens a stream to download a file
+// absolute addresses are requested as specified since they usually are presigned urls
+func (client *RestClient) Download(address string) (io.ReadCloser, error) {
+	response, err := http.Get(client.buildBaseURL(address).String())
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.checkError(response)
+	if err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
 // Post - send a POST request to hubspot
 func (client *RestClient) Post(address string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
 	builder := client.buildBaseURL(address, params...)
@@ -149,8 +244,7 @@ func (client *RestClient) Post(address string, request interface{}, params ...*P
 	return client.readResponse(response)
 }
 
-// Put - send a PUT request to hubspot
-func (client *RestClient) Put(address string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
+func (client *RestClient) send(method string, address string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
 	builder := client.buildBaseURL(address, params...)
 
 	buffer := new(bytes.Buffer)
@@ -161,14 +255,102 @@ func (client *RestClient) Put(address string, request interface{}, params ...*Pa
 		return nil, err
 	}
 
-	putrequest, err := http.NewRequest("PUT", builder.String(), buffer)
+	httprequest, err := http.NewRequest(method, builder.String(), buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	httprequest.Header.Add("Content-Type", "application/json")
+	response, err := httpclient.Do(httprequest)
 	if err != nil {
 		return nil, err
 	}
 
-	putrequest.Header.Add("Content-Type", "application/json")
-	response, err := httpclient.Do(putrequest)
+	err = client.checkError(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.readResponse(response)
+}
+
+// Put - send a PUT request to hubspot
+func (client *RestClient) Put(address string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
+	return client.send("PUT", address, request, params...)
+}
+
+// Patch - send a PATCH request to hubspot
+func (client *RestClient) Patch(address string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
+	return client.send("PATCH", address, request, params...)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes - escapes a value used as quoted string in a header (like multipart.Writer.CreateFormFile)
+func escapeQuotes(value string) string {
+	return quoteEscaper.Replace(value)
+}
+
+func writeMultipartForm(writer *multipart.Writer, form *MultipartForm) error {
+	// sort fields to send them in a deterministic order
+	keys := make([]string, 0, len(form.Fields))
+	for key := range form.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		err := writer.WriteField(key, form.Fields[key])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, file := range form.Files {
+		contenttype := file.ContentType
+		if len(contenttype) == 0 {
+			contenttype = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(file.Field), escapeQuotes(file.Name)))
+		header.Set("Content-Type", contenttype)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(part, file.Content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// PostMultipart - send a multipart POST request to hubspot
+// file contents are streamed to hubspot without buffering them in memory
+func (client *RestClient) PostMultipart(address string, form *MultipartForm, params ...*Parameter) (map[string]interface{}, error) {
+	builder := client.buildBaseURL(address, params...)
+
+	reader, pipewriter := io.Pipe()
+	writer := multipart.NewWriter(pipewriter)
+	go func() {
+		pipewriter.CloseWithError(writeMultipartForm(writer, form))
+	}()
+
+	request, err := http.NewRequest("POST", builder.String(), reader)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+
+	request.Header.Add("Content-Type", writer.FormDataContentType())
+	response, err := httpclient.Do(request)
 	if err != nil {
+		reader.Close()
 		return nil, err
 	}
 
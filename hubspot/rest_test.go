@@ -1,6 +1,12 @@
 package hubspot
 
 import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,3 +28,95 @@ func TestQuota(t *testing.T) {
 	diff := end.Sub(start)
 	require.True(t, diff > time.Millisecond*100)
 }
+
+func TestRestErrorMessage(t *testing.T) {
+	require.Equal(t, "404: 404 Not Found", (&RestError{StatusCode: 404, Status: "404 Not Found"}).Error())
+	require.Equal(t, `{"message":"gone"}`, (&RestError{StatusCode: 404, Body: `{"message":"gone"}`}).Error())
+	require.True(t, IsNotFound(&RestError{StatusCode: 404}))
+	require.False(t, IsNotFound(&RestError{StatusCode: 400}))
+}
+
+func TestPostMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "/files/v3/files", request.URL.Path)
+		require.Equal(t, "key", request.URL.Query().Get("hapikey"))
+
+		err := request.ParseMultipartForm(1024)
+		require.NoError(t, err)
+		require.Equal(t, "/invoices", request.FormValue("folderPath"))
+
+		file, header, err := request.FormFile("file")
+		require.NoError(t, err)
+		require.Equal(t, "invoice.pdf", header.Filename)
+		require.Equal(t, "application/pdf", header.Header.Get("Content-Type"))
+
+		content, _ := ioutil.ReadAll(file)
+		require.Equal(t, "%PDF-1.4", string(content))
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`{"id":"27453914"}`))
+	}))
+	defer server.Close()
+
+	rest := NewRest(server.URL+"/", "key")
+	response, err := rest.PostMultipart("files/v3/files", &MultipartForm{
+		Fields: map[string]string{"folderPath": "/invoices"},
+		Files: []*MultipartFile{
+			&MultipartFile{
+				Field:       "file",
+				Name:        "invoice.pdf",
+				ContentType: "application/pdf",
+				Content:     strings.NewReader("%PDF-1.4")}}})
+	require.NoError(t, err)
+	require.Equal(t, "27453914", response["id"])
+}
+
+func TestMultipartEscapesFileName(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writer := multipart.NewWriter(buffer)
+	err := writeMultipartForm(writer, &MultipartForm{
+		Files: []*MultipartFile{
+			&MultipartFile{
+				Field:   "file",
+				Name:    `in"voice\.pdf"; name="other`,
+				Content: strings.NewReader("%PDF-1.4")}}})
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	reader := multipart.NewReader(buffer, writer.Boundary())
+	part, err := reader.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, "file", part.FormName())
+	require.Equal(t, `in"voice\.pdf"; name="other`, part.FileName())
+}
+
+func TestGetArrayResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write([]byte(`[{"id":2071599},{"id":2071600}]`))
+	}))
+	defer server.Close()
+
+	rest := NewRest(server.URL+"/", "key")
+	response, err := rest.Get("automation/v2/workflows/enrollments/contacts/61574")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(response["results"].([]interface{})))
+}
+
+func TestBaseURLKeepsKeyOnAPIHost(t *testing.T) {
+	rest := NewRest("https://api.hubapi.com/", "key")
+
+	require.Equal(t, "https://api.hubapi.com/crm/v3/objects/deals?hapikey=key&limit=10",
+		rest.buildBaseURL("crm/v3/objects/deals", NewParameter("limit", "10")).String())
+	require.Equal(t, "https://api.hsforms.com/submissions/v3/integration/secure/submit/1/abc",
+		rest.buildBaseURL("https://api.hsforms.com/submissions/v3/integration/secure/submit/1/abc").String())
+	require.Equal(t, "https://cdn.hubspot.net/invoice.pdf?Expires=1&Signature=x%2By",
+		rest.buildBaseURL("https://cdn.hubspot.net/invoice.pdf?Expires=1", NewParameter("Signature", "x+y")).String())
+}
+
+func TestRestInterfaceImpl(t *testing.T) {
+	var rest IRestClientV3 = NewRest("https://api.hubapi.com/", "key")
+	if rest != nil {
+		return
+	}
+}
@@ -2,6 +2,8 @@ package hubspot
 
 import (
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/url"
 	"strings"
@@ -39,9 +41,33 @@ func getBodyValue(body interface{}, path string) interface{} {
 }
 
 type TestRest struct {
-	requests []string
-	bodies   []interface{}
-	Response map[string]interface{}
+	requests  []string
+	bodies    []interface{}
+	Response  map[string]interface{}
+	Responses []map[string]interface{} // responses returned in order before Response is used
+	Errors    []error                  // errors returned in order before Error is used
+	Error     error                    // error returned for every request if set
+	Content   string                   // content returned by downloads
+}
+
+func (rest *TestRest) response() map[string]interface{} {
+	if len(rest.Responses) > 0 {
+		response := rest.Responses[0]
+		rest.Responses = rest.Responses[1:]
+		return response
+	}
+
+	return rest.Response
+}
+
+func (rest *TestRest) err() error {
+	if len(rest.Errors) > 0 {
+		err := rest.Errors[0]
+		rest.Errors = rest.Errors[1:]
+		return err
+	}
+
+	return rest.Error
 }
 
 func (rest *TestRest) BeginQuota() {
@@ -97,20 +123,35 @@ func (rest *TestRest) log(url string, request interface{}, params ...*Parameter)
 
 func (rest *TestRest) Post(url string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
 	rest.log("POST "+url, request, params...)
-	return rest.Response, nil
+	return rest.response(), rest.err()
 }
 
 func (rest *TestRest) Put(url string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
 	rest.log("PUT "+url, request, params...)
-	return rest.Response, nil
+	return rest.response(), rest.err()
+}
+
+func (rest *TestRest) Patch(url string, request interface{}, params ...*Parameter) (map[string]interface{}, error) {
+	rest.log("PATCH "+url, request, params...)
+	return rest.response(), rest.err()
+}
+
+func (rest *TestRest) PostMultipart(url string, form *MultipartForm, params ...*Parameter) (map[string]interface{}, error) {
+	rest.log("POST "+url, form, params...)
+	return rest.response(), rest.err()
+}
+
+func (rest *TestRest) Download(url string) (io.ReadCloser, error) {
+	rest.log("DOWNLOAD "+url, nil)
+	return ioutil.NopCloser(strings.NewReader(rest.Content)), rest.err()
 }
 
 func (rest *TestRest) Delete(url string) error {
 	rest.log("DELETE "+url, nil)
-	return nil
+	return rest.err()
 }
 
 func (rest *TestRest) Get(url string, params ...*Parameter) (map[string]interface{}, error) {
 	rest.log("GET "+url, nil, params...)
-	return rest.Response, nil
+	return rest.response(), rest.err()
 }
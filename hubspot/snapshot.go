@@ -0,0 +1,222 @@
+package hubspot
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var snapshottype reflect.Type = reflect.TypeOf(Snapshot{})
+
+// Snapshot - state of an entity when it was loaded from hubspot
+// add a field of this type tagged with 'snapshot' to an entity to enable change tracking.
+// Entities containing a snapshot only send changed or explicitly marked properties on updates.
+type Snapshot struct {
+	values map[string]interface{} // values of properties by struct field when entity was loaded
+	dirty  map[string]bool        // struct fields explicitly marked as changed
+}
+
+// snapshotValue - get the value of a field used to detect changes
+func snapshotValue(field reflect.Value) interface{} {
+	value, isnil, _, err := getNullableValue(field)
+	if err != nil {
+		// compare the raw value if the field can't be converted
+		return field.Interface()
+	}
+
+	if isnil {
+		return nil
+	}
+
+	// copy slices so modifications of the entity are not reflected in the snapshot
+	refvalue := reflect.ValueOf(value)
+	if refvalue.Kind() == reflect.Slice && !refvalue.IsNil() {
+		copied := reflect.MakeSlice(refvalue.Type(), refvalue.Len(), refvalue.Len())
+		reflect.Copy(copied, refvalue)
+		return copied.Interface()
+	}
+
+	return value
+}
+
+// changedValue - get the value of a changed field to send to hubspot
+// changed fields are always sent, nil values clear the property.
+// Returns an error if the value can't be converted.
+func changedValue(field reflect.Value) (interface{}, bool, error) {
+	value, isnil, _, err := getNullableValue(field)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if isnil {
+		return "", true, nil
+	}
+	return value, true, nil
+}
+
+// loaded - takes a snapshot of an entity loaded from hubspot
+// returns a pointer to the entity. Strict models return the conversion errors along with the entity, which
+// only contains the values which could be converted (see readEntity).
+func (mdl *Model) loaded(entity reflect.Value, errs ConversionErrors) (interface{}, error) {
+	if mdl.snapshot != nil {
+		mdl.takeSnapshot(entity)
+	}
+
+	if mdl.options.Strict && len(errs) > 0 {
+		return entity.Addr().Interface(), errs
+	}
+
+	return entity.Addr().Interface(), nil
+}
+
+// readEntity - discards an entity read from hubspot if it couldn't be converted
+// only requests which changed an object in hubspot return the entity along with the conversion errors,
+// so the caller still knows the id of the object.
+func readEntity(entity interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (mdl *Model) takeSnapshot(entity reflect.Value) {
+	snapshot := Snapshot{values: make(map[string]interface{})}
+	for name, prop := range mdl.properties {
+		field := prop.field(entity, false)
+		if field.IsValid() {
+			snapshot.values[name] = snapshotValue(field)
+		}
+	}
+
+	if mdl.extra != nil {
+		snapshot.values[mdl.extra.StructField] = mdl.getExtraValues(entity)
+	}
+
+	field := mdl.snapshot.field(entity, true)
+	if field.IsValid() {
+		field.Set(reflect.ValueOf(snapshot))
+	}
+}
+
+// getSnapshot - get the snapshot of an entity
+// returns nil if the model does not track changes
+func (mdl *Model) getSnapshot(entity reflect.Value) *Snapshot {
+	if mdl.snapshot == nil {
+		return nil
+	}
+
+	field := mdl.snapshot.field(entity, false)
+	if !field.IsValid() {
+		return nil
+	}
+
+	snapshot := field.Interface().(Snapshot)
+	return &snapshot
+}
+
+// exportValue - get the value of a field to send to hubspot considering tracked changes
+func (snapshot *Snapshot) exportValue(mdl *Model, prop *ModelProperty, field reflect.Value) (interface{}, bool, error) {
+	if snapshot.dirty[prop.StructField] {
+		return changedValue(field)
+	}
+
+	// entities which were not loaded from hubspot send all values
+	if snapshot.values == nil {
+		return mdl.exportValue(field)
+	}
+
+	original, ok := snapshot.values[prop.StructField]
+	if ok && reflect.DeepEqual(original, snapshotValue(field)) {
+		return nil, false, nil
+	}
+
+	return changedValue(field)
+}
+
+// TakeSnapshot - takes a new snapshot of an entity
+// use this after an entity was sent to hubspot successfully to track further changes
+func (mdl *Model) TakeSnapshot(entity interface{}) error {
+	refvalue := reflect.ValueOf(entity)
+	if refvalue.Kind() != reflect.Ptr {
+		return errors.Errorf("Entity has to be passed as pointer to take a snapshot")
+	}
+
+	if mdl.snapshot == nil {
+		return errors.Errorf("Model of type '%s' has no snapshot field", mdl.datatype.Name())
+	}
+
+	mdl.takeSnapshot(refvalue.Elem())
+	return nil
+}
+
+// MarkDirty - marks fields of an entity as changed
+// marked fields are sent on the next update even if their value did not change
+//
+// **Parameters**
+//   entity: pointer to entity containing a snapshot field
+//   fields: names of struct fields to mark as changed
+func (mdl *Model) MarkDirty(entity interface{}, fields ...string) error {
+	refvalue := reflect.ValueOf(entity)
+	if refvalue.Kind() != reflect.Ptr {
+		return errors.Errorf("Entity has to be passed as pointer to mark fields as changed")
+	}
+
+	if mdl.snapshot == nil {
+		return errors.Errorf("Model of type '%s' has no snapshot field", mdl.datatype.Name())
+	}
+
+	snapshot := mdl.getSnapshot(refvalue.Elem())
+	if snapshot == nil {
+		snapshot = &Snapshot{}
+	}
+
+	dirty := make(map[string]bool)
+	for name := range snapshot.dirty {
+		dirty[name] = true
+	}
+
+	for _, name := range fields {
+		prop, ok := mdl.properties[name]
+		if !ok || !prop.writable(writeUpdate) {
+			return errors.Errorf("Field '%s' is no exported property of model '%s'", name, mdl.datatype.Name())
+		}
+		dirty[name] = true
+	}
+
+	snapshot.dirty = dirty
+	mdl.snapshot.field(refvalue.Elem(), true).Set(reflect.ValueOf(*snapshot))
+	return nil
+}
+
+// Diff - computes the properties which differ between two entities of the model
+// returns the values of changed properties by hubspot name as they would be sent to hubspot
+func (mdl *Model) Diff(original interface{}, changed interface{}) map[string]interface{} {
+	originalvalue := reflect.Indirect(reflect.ValueOf(original))
+	changedvalue := reflect.Indirect(reflect.ValueOf(changed))
+
+	diff := make(map[string]interface{})
+	for _, prop := range mdl.fieldorder {
+		if !prop.writable(writeUpdate) {
+			continue
+		}
+
+		originalfield := prop.field(originalvalue, false)
+		changedfield := prop.field(changedvalue, false)
+		if !originalfield.IsValid() || !changedfield.IsValid() {
+			continue
+		}
+
+		if reflect.DeepEqual(snapshotValue(originalfield), snapshotValue(changedfield)) {
+			continue
+		}
+
+		// values which can't be converted are not contained in the diff
+		value, ok, _ := changedValue(changedfield)
+		if ok {
+			diff[prop.HubspotName] = prop.hubspotValue(value)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,130 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type TrackedCompany struct {
+	Snapshot  `hubspot:"snapshot"`
+	ID        int64 `hubspot:"id"`
+	Name      string
+	Website   string
+	Employees *int `hubspot:"name=numberofemployees"`
+	Tags      []string
+}
+
+func loadTrackedCompany(t *testing.T, api *Companies) *TrackedCompany {
+	entity, err := api.Get(4886502)
+	require.NoError(t, err)
+	return entity.(*TrackedCompany)
+}
+
+func newTrackedCompanyRest() *TestRest {
+	return &TestRest{Response: map[string]interface{}{
+		"companyId": 4886502,
+		"properties": map[string]interface{}{
+			"name":              map[string]interface{}{"value": "vertical GmbH"},
+			"website":           map[string]interface{}{"value": "www.vertical.de"},
+			"numberofemployees": map[string]interface{}{"value": "25"}}}}
+}
+
+func TestSnapshotUpdateChangedOnly(t *testing.T) {
+	rest := newTrackedCompanyRest()
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(TrackedCompany{})))
+
+	company := loadTrackedCompany(t, api)
+	company.Website = ""
+
+	_, err := api.Update(company.ID, company)
+	require.NoError(t, err)
+
+	request := extractRequestProperties("name", rest.LastBody())
+	require.Equal(t, map[string]interface{}{"website": ""}, request)
+}
+
+func TestSnapshotCreateSendsAll(t *testing.T) {
+	rest := newTrackedCompanyRest()
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(TrackedCompany{})))
+
+	company := loadTrackedCompany(t, api)
+	company.Name = "vertical AG"
+
+	_, err := api.Create(company)
+	require.NoError(t, err)
+
+	request := extractRequestProperties("name", rest.LastBody())
+	require.Equal(t, map[string]interface{}{
+		"name":              "vertical AG",
+		"website":           "www.vertical.de",
+		"numberofemployees": 25}, request)
+}
+
+func TestSnapshotClearPointer(t *testing.T) {
+	rest := newTrackedCompanyRest()
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(TrackedCompany{})))
+
+	company := loadTrackedCompany(t, api)
+	company.Employees = nil
+	company.Tags = []string{"partner"}
+
+	_, err := api.Update(company.ID, company)
+	require.NoError(t, err)
+
+	request := extractRequestProperties("name", rest.LastBody())
+	require.Equal(t, map[string]interface{}{"numberofemployees": "", "tags": "partner"}, request)
+}
+
+func TestSnapshotMarkDirty(t *testing.T) {
+	rest := newTrackedCompanyRest()
+	model := MustNewModel(reflect.TypeOf(TrackedCompany{}))
+	api := NewCompanies(rest, model)
+
+	company := loadTrackedCompany(t, api)
+	require.NoError(t, model.MarkDirty(company, "Name"))
+	require.Error(t, model.MarkDirty(company, "ID"))
+	require.Error(t, model.MarkDirty(*company, "Name"))
+
+	_, err := api.Update(company.ID, company)
+	require.NoError(t, err)
+
+	request := extractRequestProperties("name", rest.LastBody())
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH"}, request)
+}
+
+func TestSnapshotTakeSnapshot(t *testing.T) {
+	rest := newTrackedCompanyRest()
+	model := MustNewModel(reflect.TypeOf(TrackedCompany{}))
+	api := NewCompanies(rest, model)
+
+	company := loadTrackedCompany(t, api)
+	company.Name = "vertical"
+	require.NoError(t, model.TakeSnapshot(company))
+
+	_, err := api.Update(company.ID, company)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(extractRequestProperties("name", rest.LastBody())))
+}
+
+func TestSnapshotNewEntitySendsAll(t *testing.T) {
+	rest := newTrackedCompanyRest()
+	api := NewCompanies(rest, MustNewModel(reflect.TypeOf(TrackedCompany{})))
+
+	_, err := api.Create(&TrackedCompany{Name: "vertical GmbH"})
+	require.NoError(t, err)
+
+	request := extractRequestProperties("name", rest.LastBody())
+	require.Equal(t, map[string]interface{}{"name": "vertical GmbH"}, request)
+}
+
+func TestModelDiff(t *testing.T) {
+	model := MustNewModel(reflect.TypeOf(Company{}))
+
+	original := &Company{ID: 1, Name: "vertical GmbH", Website: "www.vertical.de"}
+	changed := &Company{ID: 2, Name: "vertical GmbH", VAT: "DE123"}
+
+	diff := model.Diff(original, changed)
+	require.Equal(t, map[string]interface{}{"website": "", "umsatzsteuerid": "DE123"}, diff)
+}
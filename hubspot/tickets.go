@@ -9,6 +9,7 @@ import (
 type ITickets interface {
 	Create(ticket interface{}) (interface{}, error)
 	Get(id int64) (interface{}, error)
+	Merge(primaryid int64, mergeid int64) (*MergeResult, error)
 	Query() IQuery
 }
 
@@ -23,28 +24,34 @@ func NewTickets(rest IRestClient, model *Model) *Tickets {
 	return &Tickets{rest: rest, model: model}
 }
 
-func (api *Tickets) toEntity(response map[string]interface{}) interface{} {
+func (api *Tickets) toEntity(response map[string]interface{}) (interface{}, error) {
 	entity := reflect.New(api.model.datatype)
 	entity = entity.Elem()
 
+	var errs ConversionErrors
+
 	if api.model.id != nil {
-		api.model.id.SetValue(response, "objectId", entity)
+		errs.add(api.model.id.SetValue(response, "objectId", entity))
 	}
 
-	transferPropertiesToEntity(response, api.model, entity)
+	transferPropertiesToEntity(response, api.model, entity, &errs)
 
-	return entity.Addr().Interface()
+	return api.model.loaded(entity, errs)
 }
 
 // Create - creates a ticket in hubspot
 func (api *Tickets) Create(ticket interface{}) (interface{}, error) {
-	request := getProperties(ticket, "name", api.model)
+	request, err := getProperties(ticket, "name", api.model, writeCreate)
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := api.rest.Post("crm-objects/v1/objects/tickets", request)
 	if err != nil {
 		return nil, err
 	}
 
-	return api.toEntity(response), nil
+	return api.toEntity(response)
 }
 
 // Get - get a ticket by id
@@ -54,7 +61,13 @@ func (api *Tickets) Get(id int64) (interface{}, error) {
 		return nil, err
 	}
 
-	return api.toEntity(response), nil
+	return readEntity(api.toEntity(response))
+}
+
+// Merge - merges two tickets
+// the ticket specified by mergeid is absorbed by the primary ticket
+func (api *Tickets) Merge(primaryid int64, mergeid int64) (*MergeResult, error) {
+	return NewObjects(api.rest, "tickets", api.model).Merge(primaryid, mergeid)
 }
 
 // Query - creates a query usable to search for contacts
@@ -224,7 +224,7 @@ func TestTicketsInterfaceImpl(t *testing.T) {
 
 func TestTicketCreate(t *testing.T) {
 	rest := &TestRest{Response: readTestResponse(responseTicketCreate)}
-	api := NewTickets(rest, NewModel(reflect.TypeOf(TestTicket{})))
+	api := NewTickets(rest, MustNewModel(reflect.TypeOf(TestTicket{})))
 
 	ticket := &TestTicket{
 		Subject: "Problem hier",
@@ -246,7 +246,7 @@ func TestTicketCreate(t *testing.T) {
 
 func TestTicketGet(t *testing.T) {
 	rest := &TestRest{Response: readTestResponse(responseTicketGet)}
-	api := NewTickets(rest, NewModel(reflect.TypeOf(TestTicket{})))
+	api := NewTickets(rest, MustNewModel(reflect.TypeOf(TestTicket{})))
 
 	created, err := api.Get(176602)
 
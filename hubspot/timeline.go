@@ -0,0 +1,296 @@
+package hubspot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// maximum number of timeline events which can be sent in a single call
+const timelineBatchSize = 500
+
+// TimelineTokenOption - option of an enumeration token
+type TimelineTokenOption struct {
+	Value string
+	Label string
+}
+
+// TimelineToken - token of a timeline event template
+// tokens are filled with event data and can be used in header and detail templates
+type TimelineToken struct {
+	Name               string
+	Label              string
+	Type               string                 // one of date, enumeration, number, string
+	Options            []*TimelineTokenOption // options for enumeration tokens
+	ObjectPropertyName string                 // crm object property to update with the token value
+}
+
+// TimelineTemplate - template of timeline events
+type TimelineTemplate struct {
+	ID             string
+	Name           string
+	HeaderTemplate string // markdown template used as event header
+	DetailTemplate string // markdown template used as event details
+	ObjectType     string // type of objects events are linked to (contacts, companies, deals, tickets)
+	Tokens         []*TimelineToken
+}
+
+// TimelineEvent - event to publish on the timeline of a crm object
+type TimelineEvent struct {
+	TemplateID string
+	ID         string      // unique id of the event (generated by hubspot if empty)
+	ObjectID   int64       // id of the object the event is linked to
+	Email      string      // email of the contact the event is linked to if no object id is specified
+	Timestamp  time.Time   // time the event occured (current time if not specified)
+	Tokens     interface{} // struct containing token values, mapped using the token model of the api
+	ExtraData  interface{} // additional data available to the detail template
+}
+
+// ITimeline - interface for the hubspot timeline api
+type ITimeline interface {
+	CreateTemplate(template *TimelineTemplate) (*TimelineTemplate, error)
+	UpdateTemplate(template *TimelineTemplate) (*TimelineTemplate, error)
+	DeleteTemplate(id string) error
+	GetTemplate(id string) (*TimelineTemplate, error)
+	ListTemplates() ([]*TimelineTemplate, error)
+	Send(event *TimelineEvent) error
+	SendBatch(events []*TimelineEvent) (int, error)
+}
+
+// Timeline - access to the timeline api of hubspot using rest
+// templates have to be managed using the developer api key of the app
+type Timeline struct {
+	appid int64       // id of the app owning the event templates
+	model *Model      // model used to convert event tokens
+	rest  IRestClient // client used to send requests
+}
+
+// NewTimeline - creates a new timeline api
+//
+// **Parameters**
+//   rest : client used to send requests
+//   appid: id of the app owning the event templates
+//   model: model used to convert the token structs of events
+func NewTimeline(rest IRestClient, appid int64, model *Model) *Timeline {
+	return &Timeline{
+		appid: appid,
+		model: model,
+		rest:  rest}
+}
+
+func createTemplateRequest(template *TimelineTemplate) map[string]interface{} {
+	tokens := make([]map[string]interface{}, len(template.Tokens))
+	for index, token := range template.Tokens {
+		tokendata := map[string]interface{}{
+			"name":  token.Name,
+			"label": token.Label,
+			"type":  token.Type}
+
+		if len(token.Options) > 0 {
+			options := make([]map[string]interface{}, len(token.Options))
+			for optindex, option := range token.Options {
+				options[optindex] = map[string]interface{}{
+					"value": option.Value,
+					"label": option.Label}
+			}
+			tokendata["options"] = options
+		}
+
+		if len(token.ObjectPropertyName) > 0 {
+			tokendata["objectPropertyName"] = token.ObjectPropertyName
+		}
+		tokens[index] = tokendata
+	}
+
+	request := map[string]interface{}{
+		"name":       template.Name,
+		"objectType": template.ObjectType,
+		"tokens":     tokens}
+
+	if len(template.ID) > 0 {
+		request["id"] = template.ID
+	}
+
+	if len(template.HeaderTemplate) > 0 {
+		request["headerTemplate"] = template.HeaderTemplate
+	}
+
+	if len(template.DetailTemplate) > 0 {
+		request["detailTemplate"] = template.DetailTemplate
+	}
+
+	return request
+}
+
+func readTimelineTemplate(response map[string]interface{}) *TimelineTemplate {
+	template := &TimelineTemplate{
+		ID:             cast.ToString(response["id"]),
+		Name:           cast.ToString(response["name"]),
+		HeaderTemplate: cast.ToString(response["headerTemplate"]),
+		DetailTemplate: cast.ToString(response["detailTemplate"]),
+		ObjectType:     cast.ToString(response["objectType"])}
+
+	tokens, _ := response["tokens"].([]interface{})
+	for _, tokenobj := range tokens {
+		tokendata, ok := tokenobj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		token := &TimelineToken{
+			Name:               cast.ToString(tokendata["name"]),
+			Label:              cast.ToString(tokendata["label"]),
+			Type:               cast.ToString(tokendata["type"]),
+			ObjectPropertyName: cast.ToString(tokendata["objectPropertyName"])}
+
+		options, _ := tokendata["options"].([]interface{})
+		for _, optionobj := range options {
+			option, ok := optionobj.(map[string]interface{})
+			if ok {
+				token.Options = append(token.Options, &TimelineTokenOption{
+					Value: cast.ToString(option["value"]),
+					Label: cast.ToString(option["label"])})
+			}
+		}
+
+		template.Tokens = append(template.Tokens, token)
+	}
+
+	return template
+}
+
+// CreateTemplate - creates a new event template
+func (api *Timeline) CreateTemplate(template *TimelineTemplate) (*TimelineTemplate, error) {
+	response, err := api.rest.Post(fmt.Sprintf("crm/v3/timeline/%d/event-templates", api.appid), createTemplateRequest(template))
+	if err != nil {
+		return nil, err
+	}
+
+	return readTimelineTemplate(response), nil
+}
+
+// UpdateTemplate - updates an existing event template
+func (api *Timeline) UpdateTemplate(template *TimelineTemplate) (*TimelineTemplate, error) {
+	if len(template.ID) == 0 {
+		return nil, errors.Errorf("Template id is required to update a template")
+	}
+
+	response, err := api.rest.Put(fmt.Sprintf("crm/v3/timeline/%d/event-templates/%s", api.appid, template.ID), createTemplateRequest(template))
+	if err != nil {
+		return nil, err
+	}
+
+	return readTimelineTemplate(response), nil
+}
+
+// DeleteTemplate - deletes an event template
+func (api *Timeline) DeleteTemplate(id string) error {
+	return api.rest.Delete(fmt.Sprintf("crm/v3/timeline/%d/event-templates/%s", api.appid, id))
+}
+
+// GetTemplate - get an event template by id
+func (api *Timeline) GetTemplate(id string) (*TimelineTemplate, error) {
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/timeline/%d/event-templates/%s", api.appid, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return readTimelineTemplate(response), nil
+}
+
+// ListTemplates - lists all event templates of the app
+func (api *Timeline) ListTemplates() ([]*TimelineTemplate, error) {
+	response, err := api.rest.Get(fmt.Sprintf("crm/v3/timeline/%d/event-templates", api.appid))
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []*TimelineTemplate
+	results, _ := response["results"].([]interface{})
+	for _, obj := range results {
+		template, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		templates = append(templates, readTimelineTemplate(template))
+	}
+
+	return templates, nil
+}
+
+func (api *Timeline) createEventRequest(event *TimelineEvent) (map[string]interface{}, error) {
+	request := map[string]interface{}{
+		"eventTemplateId": event.TemplateID}
+
+	if event.ObjectID != 0 {
+		request["objectId"] = cast.ToString(event.ObjectID)
+	} else {
+		request["email"] = event.Email
+	}
+
+	if len(event.ID) > 0 {
+		request["id"] = event.ID
+	}
+
+	if !event.Timestamp.IsZero() {
+		request["timestamp"] = event.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+
+	if event.Tokens != nil {
+		tokens, err := getPropertyMap(event.Tokens, api.model, writeCreate)
+		if err != nil {
+			return nil, err
+		}
+		request["tokens"] = tokens
+	}
+
+	if event.ExtraData != nil {
+		request["extraData"] = event.ExtraData
+	}
+
+	return request, nil
+}
+
+// Send - publishes an event on the timeline of an object
+func (api *Timeline) Send(event *TimelineEvent) error {
+	request, err := api.createEventRequest(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.rest.Post("crm/v3/timeline/events", request)
+	return err
+}
+
+// SendBatch - publishes multiple events in as few calls as possible
+// returns the number of events which were sent. If an error occurs only the events before that number were
+// published, so a retry has to skip them to avoid duplicate events.
+func (api *Timeline) SendBatch(events []*TimelineEvent) (int, error) {
+	sent := 0
+	for sent < len(events) {
+		count := len(events) - sent
+		if count > timelineBatchSize {
+			count = timelineBatchSize
+		}
+
+		inputs := make([]map[string]interface{}, count)
+		for index, event := range events[sent : sent+count] {
+			request, err := api.createEventRequest(event)
+			if err != nil {
+				return sent, err
+			}
+			inputs[index] = request
+		}
+
+		_, err := api.rest.Post("crm/v3/timeline/events/batch/create", map[string]interface{}{"inputs": inputs})
+		if err != nil {
+			return sent, err
+		}
+		sent += count
+	}
+
+	return sent, nil
+}
@@ -0,0 +1,136 @@
+package hubspot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const responseTimelineTemplate string = `{
+	"id": "1001298",
+	"name": "Webinar registration",
+	"headerTemplate": "Registered for [{{webinarName}}]",
+	"detailTemplate": "Registration occured at {{#formatDate timestamp}}{{/formatDate}}",
+	"objectType": "contacts",
+	"tokens": [
+	  {
+		"name": "webinarName",
+		"label": "Webinar name",
+		"type": "string",
+		"objectPropertyName": "last_webinar"
+	  },
+	  {
+		"name": "kind",
+		"label": "Kind",
+		"type": "enumeration",
+		"options": [
+		  {"value": "live", "label": "Live"},
+		  {"value": "recorded", "label": "Recorded"}
+		]
+	  }
+	]
+  }`
+
+type WebinarTokens struct {
+	Name      string `hubspot:"name=webinarName"`
+	Kind      string
+	Attendees int
+}
+
+func TestTimelineInterfaceImpl(t *testing.T) {
+	var timeline ITimeline = &Timeline{}
+
+	if timeline != nil {
+		return
+	}
+}
+
+func TestTimelineCreateTemplate(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseTimelineTemplate)}
+	api := NewTimeline(rest, 28381, MustNewModel(reflect.TypeOf(WebinarTokens{})))
+
+	template, err := api.CreateTemplate(&TimelineTemplate{
+		Name:           "Webinar registration",
+		HeaderTemplate: "Registered for [{{webinarName}}]",
+		ObjectType:     "contacts",
+		Tokens: []*TimelineToken{
+			&TimelineToken{Name: "webinarName", Label: "Webinar name", Type: "string"},
+			&TimelineToken{Name: "kind", Label: "Kind", Type: "enumeration", Options: []*TimelineTokenOption{
+				&TimelineTokenOption{Value: "live", Label: "Live"}}}}})
+	require.NoError(t, err)
+	require.Equal(t, "POST crm/v3/timeline/28381/event-templates?hapikey=xyz", rest.LastRequest())
+
+	request := rest.LastBody().(map[string]interface{})
+	require.Equal(t, "contacts", request["objectType"])
+	require.NotContains(t, request, "id")
+	tokens := request["tokens"].([]map[string]interface{})
+	require.Equal(t, 2, len(tokens))
+	require.Equal(t, "webinarName", tokens[0]["name"])
+	require.NotContains(t, tokens[0], "options")
+	require.Equal(t, 1, len(tokens[1]["options"].([]map[string]interface{})))
+
+	require.Equal(t, "1001298", template.ID)
+	require.Equal(t, 2, len(template.Tokens))
+	require.Equal(t, "last_webinar", template.Tokens[0].ObjectPropertyName)
+	require.Equal(t, "recorded", template.Tokens[1].Options[1].Value)
+}
+
+func TestTimelineSend(t *testing.T) {
+	rest := &TestRest{}
+	api := NewTimeline(rest, 28381, MustNewModel(reflect.TypeOf(WebinarTokens{})))
+
+	err := api.Send(&TimelineEvent{
+		TemplateID: "1001298",
+		Email:      "peter@lack.de",
+		Timestamp:  time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC),
+		Tokens:     &WebinarTokens{Name: "Go basics", Kind: "live", Attendees: 12}})
+	require.NoError(t, err)
+	require.Equal(t, "POST crm/v3/timeline/events?hapikey=xyz", rest.LastRequest())
+
+	request := rest.LastBody().(map[string]interface{})
+	require.Equal(t, "1001298", request["eventTemplateId"])
+	require.Equal(t, "peter@lack.de", request["email"])
+	require.NotContains(t, request, "objectId")
+	require.Equal(t, "2020-04-01T10:00:00Z", request["timestamp"])
+	require.Equal(t, map[string]interface{}{
+		"webinarName": "Go basics",
+		"kind":        "live",
+		"attendees":   12}, request["tokens"])
+}
+
+func TestTimelineSendBatch(t *testing.T) {
+	rest := &TestRest{}
+	api := NewTimeline(rest, 28381, MustNewModel(reflect.TypeOf(WebinarTokens{})))
+
+	events := make([]*TimelineEvent, 501)
+	for index := range events {
+		events[index] = &TimelineEvent{TemplateID: "1001298", ObjectID: int64(index + 1)}
+	}
+
+	sent, err := api.SendBatch(events)
+	require.NoError(t, err)
+	require.Equal(t, 501, sent)
+	require.Equal(t, 2, len(rest.requests))
+	require.Equal(t, "POST crm/v3/timeline/events/batch/create?hapikey=xyz", rest.LastRequest())
+
+	inputs := rest.LastBody().(map[string]interface{})["inputs"].([]map[string]interface{})
+	require.Equal(t, 1, len(inputs))
+	require.Equal(t, "501", inputs[0]["objectId"])
+}
+
+func TestTimelineSendBatchPartialFailure(t *testing.T) {
+	rest := &TestRest{Errors: []error{nil, errors.New("rate limit exceeded")}}
+	api := NewTimeline(rest, 28381, MustNewModel(reflect.TypeOf(WebinarTokens{})))
+
+	events := make([]*TimelineEvent, 501)
+	for index := range events {
+		events[index] = &TimelineEvent{TemplateID: "1001298", ObjectID: int64(index + 1)}
+	}
+
+	sent, err := api.SendBatch(events)
+	require.Error(t, err)
+	require.Equal(t, 500, sent)
+}
@@ -0,0 +1,168 @@
+package hubspot
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// User - user of the hubspot portal
+type User struct {
+	ID               int64
+	Email            string
+	RoleID           string // id of the role assigned to the user (empty if user has no role)
+	PrimaryTeamID    int64
+	SecondaryTeamIDs []int64
+	SuperAdmin       bool
+}
+
+// Role - role which can be assigned to users
+type Role struct {
+	ID                   string
+	Name                 string
+	RequiresBillingWrite bool // whether assigning the role requires billing permissions
+}
+
+// Team - team of users
+type Team struct {
+	ID               int64
+	Name             string
+	UserIDs          []int64 // users with the team as primary team
+	SecondaryUserIDs []int64 // users with the team as secondary team
+}
+
+// IUsers - interface for the hubspot users api
+type IUsers interface {
+	List(page *Page) (*PageResponse, error)
+	Get(id int64) (*User, error)
+	GetByEmail(email string) (*User, error)
+	Roles() ([]*Role, error)
+	Teams() ([]*Team, error)
+}
+
+// Users - access to users, roles and teams of hubspot using rest
+type Users struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewUsers - creates a new users api
+func NewUsers(rest IRestClient) *Users {
+	return &Users{rest: rest}
+}
+
+func readUser(response map[string]interface{}) *User {
+	return &User{
+		ID:               cast.ToInt64(response["id"]),
+		Email:            cast.ToString(response["email"]),
+		RoleID:           cast.ToString(response["roleId"]),
+		PrimaryTeamID:    cast.ToInt64(response["primaryTeamId"]),
+		SecondaryTeamIDs: toInt64Slice(response["secondaryTeamIds"]),
+		SuperAdmin:       cast.ToBool(response["superAdmin"])}
+}
+
+// readResults - get objects contained in the results of a response
+func readResults(response map[string]interface{}) ([]map[string]interface{}, error) {
+	var objects []map[string]interface{}
+
+	results, _ := response["results"].([]interface{})
+	for _, obj := range results {
+		data, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		objects = append(objects, data)
+	}
+
+	return objects, nil
+}
+
+// List - lists a page of users
+func (api *Users) List(page *Page) (*PageResponse, error) {
+	response, err := api.rest.Get("settings/v3/users/", getPageParameters(page)...)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := new(PageResponse)
+	readPaging(response, pr)
+
+	results, err := readResults(response)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range results {
+		pr.Data = append(pr.Data, readUser(user))
+	}
+
+	return pr, nil
+}
+
+// Get - get a user by id
+func (api *Users) Get(id int64) (*User, error) {
+	response, err := api.rest.Get(fmt.Sprintf("settings/v3/users/%d", id))
+	if err != nil {
+		return nil, notFound(err, "user", id)
+	}
+
+	return readUser(response), nil
+}
+
+// GetByEmail - get a user by its email address
+func (api *Users) GetByEmail(email string) (*User, error) {
+	response, err := api.rest.Get(fmt.Sprintf("settings/v3/users/%s", email), NewParameter("idProperty", "EMAIL"))
+	if err != nil {
+		return nil, notFound(err, "user", email)
+	}
+
+	return readUser(response), nil
+}
+
+// Roles - lists all roles of the portal
+func (api *Users) Roles() ([]*Role, error) {
+	response, err := api.rest.Get("settings/v3/users/roles")
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := readResults(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []*Role
+	for _, role := range results {
+		roles = append(roles, &Role{
+			ID:                   cast.ToString(role["id"]),
+			Name:                 cast.ToString(role["name"]),
+			RequiresBillingWrite: cast.ToBool(role["requiresBillingWrite"])})
+	}
+
+	return roles, nil
+}
+
+// Teams - lists all teams of the portal
+func (api *Users) Teams() ([]*Team, error) {
+	response, err := api.rest.Get("settings/v3/users/teams")
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := readResults(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []*Team
+	for _, team := range results {
+		teams = append(teams, &Team{
+			ID:               cast.ToInt64(team["id"]),
+			Name:             cast.ToString(team["name"]),
+			UserIDs:          toInt64Slice(team["userIds"]),
+			SecondaryUserIDs: toInt64Slice(team["secondaryUserIds"])})
+	}
+
+	return teams, nil
+}
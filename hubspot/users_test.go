@@ -0,0 +1,93 @@
+package hubspot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseUserList string = `{
+	"results": [
+	  {
+		"id": "9586504",
+		"email": "peter@vertical.de",
+		"roleId": "100",
+		"primaryTeamId": "6679375",
+		"secondaryTeamIds": ["6679376"],
+		"superAdmin": true
+	  }
+	],
+	"paging": {
+	  "next": {
+		"after": "9586504"
+	  }
+	}
+  }`
+
+const responseTeamList string = `{
+	"results": [
+	  {
+		"id": "6679375",
+		"name": "Sales",
+		"userIds": ["9586504", "9586505"],
+		"secondaryUserIds": []
+	  }
+	]
+  }`
+
+func TestUsersInterfaceImpl(t *testing.T) {
+	var users IUsers = &Users{}
+
+	if users != nil {
+		return
+	}
+}
+
+func TestUsersList(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseUserList)}
+	api := NewUsers(rest)
+
+	response, err := api.List(nil)
+	require.NoError(t, err)
+	require.Equal(t, "GET settings/v3/users/?hapikey=xyz", rest.LastRequest())
+	require.True(t, response.HasMore)
+	require.Equal(t, 1, len(response.Data))
+
+	user := response.Data[0].(*User)
+	require.Equal(t, int64(9586504), user.ID)
+	require.Equal(t, "100", user.RoleID)
+	require.Equal(t, int64(6679375), user.PrimaryTeamID)
+	require.Equal(t, []int64{6679376}, user.SecondaryTeamIDs)
+	require.True(t, user.SuperAdmin)
+}
+
+func TestUsersGetByEmail(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"id": "9586504", "email": "peter@vertical.de"}}
+	api := NewUsers(rest)
+
+	user, err := api.GetByEmail("peter@vertical.de")
+	require.NoError(t, err)
+	require.Equal(t, "GET settings/v3/users/peter@vertical.de?hapikey=xyz&idProperty=EMAIL", rest.LastRequest())
+	require.Equal(t, int64(9586504), user.ID)
+}
+
+func TestUsersGetNotFound(t *testing.T) {
+	rest := &TestRest{Error: &RestError{StatusCode: 404}}
+	api := NewUsers(rest)
+
+	_, err := api.Get(9586504)
+	require.True(t, IsNotFound(err))
+}
+
+func TestUsersTeams(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseTeamList)}
+	api := NewUsers(rest)
+
+	teams, err := api.Teams()
+	require.NoError(t, err)
+	require.Equal(t, "GET settings/v3/users/teams?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, 1, len(teams))
+	require.Equal(t, "Sales", teams[0].Name)
+	require.Equal(t, []int64{9586504, 9586505}, teams[0].UserIDs)
+	require.Equal(t, 0, len(teams[0].SecondaryUserIDs))
+}
@@ -0,0 +1,158 @@
+package hubspot
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// Workflow - automation workflow in hubspot
+type Workflow struct {
+	ID        int64
+	Name      string
+	Type      string // eg. DRIP_DELAY, STATIC_ANCHOR, PROPERTY_ANCHOR
+	Enabled   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IWorkflows - interface for the hubspot workflows api
+type IWorkflows interface {
+	List() ([]*Workflow, error)
+	Get(id int64) (*Workflow, error)
+	Enroll(workflowid int64, email string) error
+	EnrollByID(workflowid int64, contactid int64) error
+	Unenroll(workflowid int64, email string) error
+	UnenrollByID(workflowid int64, contactid int64) error
+	Enrollments(contactid int64) ([]*Workflow, error)
+}
+
+// Workflows - access to the workflows api of hubspot using rest
+type Workflows struct {
+	rest IRestClient // client used to send requests
+}
+
+// NewWorkflows - creates a new workflows api
+func NewWorkflows(rest IRestClient) *Workflows {
+	return &Workflows{rest: rest}
+}
+
+func readWorkflow(response map[string]interface{}) (*Workflow, error) {
+	createdat, updatedat, err := readTimestamps(response, "insertedAt", "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Workflow{
+		ID:        cast.ToInt64(response["id"]),
+		Name:      cast.ToString(response["name"]),
+		Type:      cast.ToString(response["type"]),
+		Enabled:   cast.ToBool(response["enabled"]),
+		CreatedAt: createdat,
+		UpdatedAt: updatedat}, nil
+}
+
+func readWorkflows(items interface{}) ([]*Workflow, error) {
+	var workflows []*Workflow
+
+	results, _ := items.([]interface{})
+	for _, obj := range results {
+		workflow, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Unexpected response structure from hubspot")
+		}
+
+		item, err := readWorkflow(workflow)
+		if err != nil {
+			return nil, err
+		}
+
+		workflows = append(workflows, item)
+	}
+
+	return workflows, nil
+}
+
+// List - lists all workflows of the portal
+func (api *Workflows) List() ([]*Workflow, error) {
+	response, err := api.rest.Get("automation/v3/workflows")
+	if err != nil {
+		return nil, err
+	}
+
+	return readWorkflows(response["workflows"])
+}
+
+// Get - get a workflow by id
+func (api *Workflows) Get(id int64) (*Workflow, error) {
+	response, err := api.rest.Get(fmt.Sprintf("automation/v3/workflows/%d", id))
+	if err != nil {
+		return nil, notFound(err, "workflow", id)
+	}
+
+	return readWorkflow(response)
+}
+
+// getEmail - get the email of a contact since workflows only accept emails for enrollment
+func (api *Workflows) getEmail(contactid int64) (string, error) {
+	response, err := api.rest.Get(fmt.Sprintf("contacts/v1/contact/vid/%d/profile", contactid),
+		NewParameter("property", "email"),
+		NewParameter("propertyMode", "value_only"))
+	if err != nil {
+		return "", notFound(err, "contact", contactid)
+	}
+
+	properties, _ := response["properties"].(map[string]interface{})
+	email, _ := properties["email"].(map[string]interface{})
+	value := cast.ToString(email["value"])
+	if len(value) == 0 {
+		return "", errors.Errorf("Contact %d has no email and can not be enrolled in workflows", contactid)
+	}
+
+	return value, nil
+}
+
+// Enroll - enrolls a contact in a workflow
+func (api *Workflows) Enroll(workflowid int64, email string) error {
+	_, err := api.rest.Post(fmt.Sprintf("automation/v2/workflows/%d/enrollments/contacts/%s", workflowid, url.PathEscape(email)),
+		map[string]interface{}{})
+	return err
+}
+
+// EnrollByID - enrolls a contact identified by id in a workflow
+func (api *Workflows) EnrollByID(workflowid int64, contactid int64) error {
+	email, err := api.getEmail(contactid)
+	if err != nil {
+		return err
+	}
+
+	return api.Enroll(workflowid, email)
+}
+
+// Unenroll - removes a contact from a workflow
+func (api *Workflows) Unenroll(workflowid int64, email string) error {
+	return api.rest.Delete(fmt.Sprintf("automation/v2/workflows/%d/enrollments/contacts/%s", workflowid, url.PathEscape(email)))
+}
+
+// UnenrollByID - removes a contact identified by id from a workflow
+func (api *Workflows) UnenrollByID(workflowid int64, contactid int64) error {
+	email, err := api.getEmail(contactid)
+	if err != nil {
+		return err
+	}
+
+	return api.Unenroll(workflowid, email)
+}
+
+// Enrollments - lists workflows a contact is currently enrolled in
+func (api *Workflows) Enrollments(contactid int64) ([]*Workflow, error) {
+	response, err := api.rest.Get(fmt.Sprintf("automation/v2/workflows/enrollments/contacts/%d", contactid))
+	if err != nil {
+		return nil, err
+	}
+
+	return readWorkflows(response[arrayResponseKey])
+}
@@ -0,0 +1,108 @@
+package hubspot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const responseWorkflowList string = `{
+	"workflows": [
+	  {
+		"id": 2071599,
+		"name": "Onboarding",
+		"type": "DRIP_DELAY",
+		"enabled": true,
+		"insertedAt": 1585735200000,
+		"updatedAt": 1585735200000
+	  },
+	  {
+		"id": 2071600,
+		"name": "Renewal reminder",
+		"type": "PROPERTY_ANCHOR",
+		"enabled": false
+	  }
+	]
+  }`
+
+func TestWorkflowsInterfaceImpl(t *testing.T) {
+	var workflows IWorkflows = &Workflows{}
+
+	if workflows != nil {
+		return
+	}
+}
+
+func TestWorkflowList(t *testing.T) {
+	rest := &TestRest{Response: readTestResponse(responseWorkflowList)}
+	api := NewWorkflows(rest)
+
+	workflows, err := api.List()
+	require.NoError(t, err)
+	require.Equal(t, "GET automation/v3/workflows?hapikey=xyz", rest.LastRequest())
+
+	require.Equal(t, 2, len(workflows))
+	require.Equal(t, int64(2071599), workflows[0].ID)
+	require.Equal(t, "Onboarding", workflows[0].Name)
+	require.True(t, workflows[0].Enabled)
+	require.Equal(t, 2020, workflows[0].CreatedAt.Year())
+	require.False(t, workflows[1].Enabled)
+}
+
+func TestWorkflowEnroll(t *testing.T) {
+	rest := &TestRest{}
+	api := NewWorkflows(rest)
+
+	err := api.Enroll(2071599, "peter@lack.de")
+	require.NoError(t, err)
+	require.Equal(t, "POST automation/v2/workflows/2071599/enrollments/contacts/peter@lack.de?hapikey=xyz", rest.LastRequest())
+
+	err = api.Unenroll(2071599, "peter@lack.de")
+	require.NoError(t, err)
+	require.Equal(t, "DELETE automation/v2/workflows/2071599/enrollments/contacts/peter@lack.de?hapikey=xyz", rest.LastRequest())
+}
+
+func TestWorkflowEnrollEscapesEmail(t *testing.T) {
+	rest := &TestRest{}
+	api := NewWorkflows(rest)
+
+	err := api.Enroll(2071599, "peter/lack?@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "POST automation/v2/workflows/2071599/enrollments/contacts/peter%2Flack%3F@example.com?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, map[string]interface{}{}, rest.LastBody())
+}
+
+func TestWorkflowEnrollByID(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"vid": 61574,
+		"properties": map[string]interface{}{
+			"email": map[string]interface{}{"value": "peter@lack.de"}}}}
+	api := NewWorkflows(rest)
+
+	err := api.EnrollByID(2071599, 61574)
+	require.NoError(t, err)
+	require.Equal(t, "GET contacts/v1/contact/vid/61574/profile?hapikey=xyz&property=email&propertyMode=value_only", rest.requests[0])
+	require.Equal(t, "POST automation/v2/workflows/2071599/enrollments/contacts/peter@lack.de?hapikey=xyz", rest.LastRequest())
+}
+
+func TestWorkflowEnrollByIDWithoutEmail(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{"vid": 61574}}
+	api := NewWorkflows(rest)
+
+	err := api.EnrollByID(2071599, 61574)
+	require.Error(t, err)
+	require.Equal(t, 1, len(rest.requests))
+}
+
+func TestWorkflowEnrollments(t *testing.T) {
+	rest := &TestRest{Response: map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"id": float64(2071599), "name": "Onboarding"}}}}
+	api := NewWorkflows(rest)
+
+	workflows, err := api.Enrollments(61574)
+	require.NoError(t, err)
+	require.Equal(t, "GET automation/v2/workflows/enrollments/contacts/61574?hapikey=xyz", rest.LastRequest())
+	require.Equal(t, 1, len(workflows))
+	require.Equal(t, "Onboarding", workflows[0].Name)
+}